@@ -0,0 +1,170 @@
+// apierrors/apierrors.go
+/* Package apierrors defines the errors returned by the client that callers can branch on with errors.Is and
+errors.As. API error responses (*response.APIError), retried requests, concurrency permits and token acquisition
+all return errors wrapping these sentinels, e.g.
+
+	if errors.Is(err, apierrors.ErrNotFound) { ... }
+
+	var rateLimited *apierrors.RateLimitError
+	if errors.As(err, &rateLimited) { time.Sleep(rateLimited.RetryAfter) } */
+package apierrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sentinel errors.
+var (
+	ErrUnauthorized     = errors.New("unauthorized")                               // ErrUnauthorized: 401, credentials missing, invalid or expired.
+	ErrForbidden        = errors.New("forbidden")                                  // ErrForbidden: 403, the account lacks permission for the resource.
+	ErrNotFound         = errors.New("not found")                                  // ErrNotFound: 404, the resource does not exist.
+	ErrConflict         = errors.New("conflict")                                   // ErrConflict: 409, the request conflicts with the state of the resource.
+	ErrRateLimited      = errors.New("rate limited")                               // ErrRateLimited: 429, see RateLimitError for the retry-after value.
+	ErrTransient        = errors.New("transient error")                            // ErrTransient: 408, 500, 502, 503 or 504, the request may succeed if retried.
+	ErrRetriesExhausted = errors.New("retries exhausted")                          // ErrRetriesExhausted: see RetriesExhaustedError for the attempt history.
+	ErrPermitTimeout    = errors.New("timed out waiting for a concurrency permit") // ErrPermitTimeout: no concurrency permit became available in time.
+	ErrCircuitOpen      = errors.New("circuit breaker open")                       // ErrCircuitOpen: requests fail fast after repeated failures, see CircuitOpenError.
+	ErrOverloaded       = errors.New("concurrency permit queue full")              // ErrOverloaded: the request was shed because too many requests were waiting, see OverloadError.
+)
+
+// ForStatusCode returns the sentinel error for an HTTP status code, or nil when the status code has none.
+// A 429 status returns ErrRateLimited; use NewRateLimitError to carry the retry-after value.
+func ForStatusCode(statusCode int) error {
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrTransient
+	}
+	return nil
+}
+
+// WithStatusCode wraps err so that it also matches the sentinel error for the HTTP status code.
+// err is returned unchanged if it is nil or the status code has no sentinel.
+func WithStatusCode(err error, statusCode int) error {
+	sentinel := ForStatusCode(statusCode)
+	if err == nil || sentinel == nil {
+		return err
+	}
+	return &statusError{err: err, sentinel: sentinel}
+}
+
+// statusError pairs an error with the sentinel for the status code it was caused by.
+type statusError struct {
+	err      error
+	sentinel error
+}
+
+func (e *statusError) Error() string   { return e.err.Error() }
+func (e *statusError) Unwrap() []error { return []error{e.err, e.sentinel} }
+
+// RateLimitError reports a 429 response. It matches ErrRateLimited.
+type RateLimitError struct {
+	RetryAfter time.Duration // RetryAfter is the wait requested by the server, or zero if it did not send one.
+}
+
+// NewRateLimitError returns a RateLimitError carrying the retry-after value.
+func NewRateLimitError(retryAfter time.Duration) *RateLimitError {
+	return &RateLimitError{RetryAfter: retryAfter}
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited, retry after %s", e.RetryAfter)
+	}
+	return ErrRateLimited.Error()
+}
+
+// Is reports whether target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// OverloadError reports a request that was shed because the queue of requests waiting for a concurrency permit was
+// full. It matches ErrOverloaded.
+type OverloadError struct {
+	QueueDepth int    // QueueDepth is the maximum number of waiting requests.
+	Priority   string // Priority is the priority of the shed request.
+}
+
+func (e *OverloadError) Error() string {
+	return fmt.Sprintf("%s: %d requests waiting, shed %s priority request", ErrOverloaded, e.QueueDepth, e.Priority)
+}
+
+// Is reports whether target is ErrOverloaded.
+func (e *OverloadError) Is(target error) bool {
+	return target == ErrOverloaded
+}
+
+// CircuitOpenError reports a request that was not sent because the circuit breaker for its host or endpoint group
+// is open. It matches ErrCircuitOpen.
+type CircuitOpenError struct {
+	Key        string        // Key is the host or endpoint group the circuit breaker protects.
+	RetryAfter time.Duration // RetryAfter is the time left until the circuit breaker lets a probe request through.
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s for %s, retry after %s", ErrCircuitOpen, e.Key, e.RetryAfter)
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// Attempt records one attempt of a retried request.
+type Attempt struct {
+	Number     int                 // Number is the 1-based attempt number.
+	StatusCode int                 // StatusCode is the response status, or zero if no response was received.
+	Err        error               // Err is the transport error, if the request failed without a response.
+	Class      TransportErrorClass // Class classifies Err, empty when a response was received.
+	Wait       time.Duration       // Wait is the delay before the next attempt, zero for the last attempt.
+}
+
+// RetriesExhaustedError reports that a request failed on every attempt allowed by the retry configuration.
+// It matches ErrRetriesExhausted and unwraps to the error of the last attempt.
+type RetriesExhaustedError struct {
+	Attempts []Attempt // Attempts is the history of every attempt made.
+	Err      error     // Err is the error of the last attempt.
+}
+
+func (e *RetriesExhaustedError) Error() string {
+	statuses := make([]string, len(e.Attempts))
+	for i, attempt := range e.Attempts {
+		switch {
+		case attempt.Err != nil && attempt.Class != "":
+			statuses[i] = fmt.Sprintf("%s: %s", attempt.Class, attempt.Err)
+		case attempt.Err != nil:
+			statuses[i] = attempt.Err.Error()
+		default:
+			statuses[i] = fmt.Sprint(attempt.StatusCode)
+		}
+	}
+	message := fmt.Sprintf("%s after %d attempts [%s]", ErrRetriesExhausted, len(e.Attempts), strings.Join(statuses, ", "))
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Is reports whether target is ErrRetriesExhausted.
+func (e *RetriesExhaustedError) Is(target error) bool {
+	return target == ErrRetriesExhausted
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetriesExhaustedError) Unwrap() error {
+	return e.Err
+}
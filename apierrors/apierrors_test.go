@@ -0,0 +1,88 @@
+// apierrors/apierrors_test.go
+package apierrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestForStatusCode tests the mapping of HTTP status codes to sentinel errors.
+func TestForStatusCode(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		expected   error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusRequestTimeout, ErrTransient},
+		{http.StatusServiceUnavailable, ErrTransient},
+		{http.StatusBadRequest, nil},
+		{http.StatusOK, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			assert.Equal(t, tt.expected, ForStatusCode(tt.statusCode))
+		})
+	}
+}
+
+// TestWithStatusCode tests that wrapped errors match both the original error and the status sentinel.
+func TestWithStatusCode(t *testing.T) {
+	original := errors.New("received non-OK response status: 401")
+	err := WithStatusCode(original, http.StatusUnauthorized)
+
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.ErrorIs(t, err, original)
+	assert.Equal(t, original.Error(), err.Error())
+
+	assert.Same(t, original, WithStatusCode(original, http.StatusBadRequest))
+	assert.Nil(t, WithStatusCode(nil, http.StatusUnauthorized))
+}
+
+// TestRateLimitError tests that RateLimitError matches ErrRateLimited and carries the retry-after value.
+func TestRateLimitError(t *testing.T) {
+	err := fmt.Errorf("request failed: %w", NewRateLimitError(30*time.Second))
+
+	assert.ErrorIs(t, err, ErrRateLimited)
+	var rateLimited *RateLimitError
+	require.ErrorAs(t, err, &rateLimited)
+	assert.Equal(t, 30*time.Second, rateLimited.RetryAfter)
+	assert.Equal(t, "request failed: rate limited, retry after 30s", err.Error())
+}
+
+// TestRetriesExhaustedError tests that RetriesExhaustedError matches ErrRetriesExhausted and unwraps to the last error.
+func TestRetriesExhaustedError(t *testing.T) {
+	last := WithStatusCode(errors.New("service unavailable"), http.StatusServiceUnavailable)
+	err := &RetriesExhaustedError{
+		Attempts: []Attempt{
+			{Number: 1, StatusCode: http.StatusServiceUnavailable, Wait: time.Second},
+			{Number: 2, Err: errors.New("connection reset"), Wait: 2 * time.Second},
+			{Number: 3, StatusCode: http.StatusServiceUnavailable},
+		},
+		Err: last,
+	}
+
+	assert.ErrorIs(t, err, ErrRetriesExhausted)
+	assert.ErrorIs(t, err, ErrTransient)
+	assert.Equal(t, "retries exhausted after 3 attempts [503, connection reset, 503]: service unavailable", err.Error())
+}
+
+// TestCircuitOpenError tests that CircuitOpenError matches ErrCircuitOpen when wrapped by the transport.
+func TestCircuitOpenError(t *testing.T) {
+	err := fmt.Errorf("Get \"https://acme.jamfcloud.com\": %w", &CircuitOpenError{Key: "acme.jamfcloud.com", RetryAfter: 30 * time.Second})
+
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	var openErr *CircuitOpenError
+	require.ErrorAs(t, err, &openErr)
+	assert.Equal(t, "circuit breaker open for acme.jamfcloud.com, retry after 30s", openErr.Error())
+}
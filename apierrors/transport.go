@@ -0,0 +1,89 @@
+// apierrors/transport.go
+package apierrors
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// TransportErrorClass classifies an error returned when a request got no response.
+type TransportErrorClass string
+
+// Transport error classes.
+const (
+	TransportErrorDNS               TransportErrorClass = "dns"                // Temporary DNS lookup failure
+	TransportErrorDNSNotFound       TransportErrorClass = "dns_not_found"      // The host does not exist
+	TransportErrorConnectionRefused TransportErrorClass = "connection_refused" // Nothing listens on the port
+	TransportErrorConnectionReset   TransportErrorClass = "connection_reset"   // The connection was reset, aborted or broken
+	TransportErrorTLSHandshake      TransportErrorClass = "tls_handshake"      // The TLS handshake failed or timed out
+	TransportErrorTLSCertificate    TransportErrorClass = "tls_certificate"    // The server certificate was rejected
+	TransportErrorTimeout           TransportErrorClass = "timeout"            // Dialing, sending or reading timed out
+	TransportErrorUnexpectedEOF     TransportErrorClass = "unexpected_eof"     // The server closed the connection mid-exchange
+	TransportErrorGoAway            TransportErrorClass = "http2_goaway"       // The HTTP/2 server shut the connection down with GOAWAY
+	TransportErrorCanceled          TransportErrorClass = "canceled"           // The request context was canceled
+	TransportErrorOther             TransportErrorClass = "other"              // Any other error, e.g. an open circuit breaker
+)
+
+// ClassifyTransportError returns the class of err, or an empty class if err is nil.
+func ClassifyTransportError(err error) TransportErrorClass {
+	if err == nil {
+		return ""
+	}
+
+	var dnsErr *net.DNSError
+	var unknownAuthority x509.UnknownAuthorityError
+	var invalidCertificate x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	var verificationErr *tls.CertificateVerificationError
+	var recordHeaderErr tls.RecordHeaderError
+	var alertErr tls.AlertError
+	var netErr net.Error
+	message := err.Error()
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return TransportErrorCanceled
+	case errors.Is(err, ErrCircuitOpen):
+		return TransportErrorOther
+	case errors.As(err, &dnsErr):
+		if dnsErr.IsNotFound {
+			return TransportErrorDNSNotFound
+		}
+		return TransportErrorDNS
+	case errors.As(err, &unknownAuthority), errors.As(err, &invalidCertificate), errors.As(err, &hostnameErr),
+		errors.As(err, &verificationErr):
+		return TransportErrorTLSCertificate
+	case errors.As(err, &recordHeaderErr), errors.As(err, &alertErr), strings.Contains(message, "TLS handshake"):
+		return TransportErrorTLSHandshake
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return TransportErrorConnectionRefused
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.ECONNABORTED), errors.Is(err, syscall.EPIPE):
+		return TransportErrorConnectionReset
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return TransportErrorTimeout
+	case strings.Contains(message, "GOAWAY"):
+		return TransportErrorGoAway
+	case errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		return TransportErrorUnexpectedEOF
+	default:
+		return TransportErrorOther
+	}
+}
+
+// Retryable reports whether a request failing with an error of this class may succeed when sent again. Missing
+// hosts, rejected certificates, canceled requests and unclassified errors are not retried.
+func (c TransportErrorClass) Retryable() bool {
+	switch c {
+	case TransportErrorDNS, TransportErrorConnectionRefused, TransportErrorConnectionReset, TransportErrorTLSHandshake,
+		TransportErrorTimeout, TransportErrorUnexpectedEOF, TransportErrorGoAway:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,58 @@
+// apierrors/transport_test.go
+package apierrors
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClassifyTransportError tests the classification of errors returned by http.Client.Do.
+func TestClassifyTransportError(t *testing.T) {
+	wrap := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://acme.jamfcloud.com/api", Err: err}
+	}
+	dial := func(errno syscall.Errno) error {
+		return wrap(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", errno)})
+	}
+
+	tests := []struct {
+		name      string
+		err       error
+		expected  TransportErrorClass
+		retryable bool
+	}{
+		{"DNSTemporary", wrap(&net.DNSError{Err: "server misbehaving", Name: "acme.jamfcloud.com", IsTemporary: true}), TransportErrorDNS, true},
+		{"DNSNotFound", wrap(&net.DNSError{Err: "no such host", Name: "acme.jamfcloud.com", IsNotFound: true}), TransportErrorDNSNotFound, false},
+		{"ConnectionRefused", dial(syscall.ECONNREFUSED), TransportErrorConnectionRefused, true},
+		{"ConnectionReset", dial(syscall.ECONNRESET), TransportErrorConnectionReset, true},
+		{"TLSHandshakeTimeout", wrap(errors.New("net/http: TLS handshake timeout")), TransportErrorTLSHandshake, true},
+		{"UnknownAuthority", wrap(x509.UnknownAuthorityError{}), TransportErrorTLSCertificate, false},
+		{"Timeout", wrap(context.DeadlineExceeded), TransportErrorTimeout, true},
+		{"UnexpectedEOF", wrap(io.ErrUnexpectedEOF), TransportErrorUnexpectedEOF, true},
+		{"EOF", wrap(io.EOF), TransportErrorUnexpectedEOF, true},
+		{"GoAway", wrap(errors.New("http2: server sent GOAWAY and closed the connection; LastStreamID=1, ErrCode=NO_ERROR")), TransportErrorGoAway, true},
+		{"Canceled", wrap(context.Canceled), TransportErrorCanceled, false},
+		{"CircuitOpen", wrap(fmt.Errorf("%w", &CircuitOpenError{Key: "acme.jamfcloud.com"})), TransportErrorOther, false},
+		{"Other", errors.New("unsupported protocol scheme"), TransportErrorOther, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class := ClassifyTransportError(tt.err)
+			assert.Equal(t, tt.expected, class)
+			assert.Equal(t, tt.retryable, class.Retryable())
+		})
+	}
+
+	assert.Equal(t, TransportErrorClass(""), ClassifyTransportError(nil))
+}
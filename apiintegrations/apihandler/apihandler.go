@@ -2,10 +2,12 @@
 package apihandler
 
 import (
-	"github.com/deploymenttheory/go-api-http-client/apiintegrations/jamfpro"
-	"github.com/deploymenttheory/go-api-http-client/apiintegrations/msgraph"
+	"net/http"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/concurrency"
 	"github.com/deploymenttheory/go-api-http-client/logger"
-	"go.uber.org/zap"
+	"github.com/deploymenttheory/go-api-http-client/response"
 )
 
 // APIHandler is an interface for encoding, decoding, and implenting contexual api functions for different API implementations.
@@ -29,27 +31,59 @@ type APIHandler interface {
 	GetAPIRequestHeaders(endpoint string) map[string]string // Provides standard headers required for making API requests.
 }
 
+// TokenResponseParser is an optional interface implemented by API handlers whose token endpoints respond with
+// a body other than the standard bearer ({"token", "expires"}) or OAuth ({"access_token", "expires_in"}) formats.
+// When implemented, the authentication handler uses it to extract the token and its expiry from the response body.
+// A zero expiry means the token does not expire.
+type TokenResponseParser interface {
+	ParseTokenResponse(body []byte) (token string, expires time.Time, err error)
+}
+
+// ErrorResponseParser is an optional interface implemented by API handlers that understand the error bodies of
+// their API. When implemented, the client passes error responses to it after the generic content type based
+// parsing, so that it can fill in the code, target, details and correlation IDs of the APIError, or return an
+// API specific error type wrapping it.
+type ErrorResponseParser interface {
+	ParseErrorResponse(resp *http.Response, body []byte, apiError *response.APIError) error
+}
+
+// APIVersionSelector is an optional interface implemented by API handlers whose API serves several versions side
+// by side. The client uses it for requests that select a version with RequestOptions.APIVersion; an empty version
+// selects the handler's configured one.
+type APIVersionSelector interface {
+	ConstructVersionedAPIResourceEndpoint(endpointPath, version string, log logger.Logger) (string, error)
+}
+
+// OAuthRefreshTokenRequirer is an optional interface implemented by API handlers whose OAuth token endpoint has no
+// client credentials grant and only issues access tokens for a refresh token, as on GitHub. The client rejects
+// OAuth credentials without a refresh token for handlers requiring one.
+type OAuthRefreshTokenRequirer interface {
+	RequiresOAuthRefreshToken() bool
+}
+
+// GitHubAppAuthenticationSupporter is an optional interface implemented by API handlers that can authenticate as a
+// GitHub App installation. The client rejects GitHub App credentials for handlers that do not implement it or
+// report no support.
+type GitHubAppAuthenticationSupporter interface {
+	GetAPIGitHubAppAuthenticationSupportStatus() bool
+}
+
+// ConcurrencyDefaultsProvider is an optional interface implemented by API handlers whose API tolerates more or less
+// load than the concurrency package defaults. The client uses the tuning it returns for the fields its own
+// concurrency configuration leaves zero; fields the handler leaves zero take the package defaults.
+type ConcurrencyDefaultsProvider interface {
+	ConcurrencyDefaults() concurrency.Tuning
+}
+
 // LoadAPIHandler loads the appropriate API handler based on the API type.
+//
+// Deprecated: use NewAPIHandler, which also passes handler specific options to the factory.
 func LoadAPIHandler(apiType, instanceName, tenantID, tenantName string, log logger.Logger) (APIHandler, error) {
-	var apiHandler APIHandler
-	switch apiType {
-	case "jamfpro":
-		apiHandler = &jamfpro.JamfAPIHandler{
-			Logger:       log,
-			InstanceName: instanceName, // Used for constructing both jamf pro resource and auth endpoints
-		}
-		log.Info("Jamf Pro API handler loaded successfully", zap.String("APIType", apiType), zap.String("InstanceName", instanceName))
-
-	case "msgraph":
-		apiHandler = &msgraph.GraphAPIHandler{
-			Logger:   log,
-			TenantID: tenantID, // Used for constructing the graph auth endpoint
-		}
-		log.Info("Microsoft Graph API handler loaded successfully", zap.String("APIType", apiType), zap.String("TenantID", tenantID), zap.String("TenantName", tenantName))
-
-	default:
-		return nil, log.Error("Unsupported API type", zap.String("APIType", apiType))
-	}
-
-	return apiHandler, nil
+	return NewAPIHandler(HandlerConfig{
+		APIType:      apiType,
+		InstanceName: instanceName,
+		TenantID:     tenantID,
+		TenantName:   tenantName,
+		Logger:       log,
+	})
 }
@@ -0,0 +1,186 @@
+// apiintegrations/apihandler/registry.go
+package apihandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/endpointconfig"
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/generic"
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/github"
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/jamfpro"
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/msgraph"
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"go.uber.org/zap"
+)
+
+// HandlerConfig holds the settings passed to an API handler factory when a handler is loaded.
+// The common environment settings are provided as fields; anything specific to a handler is
+// carried in Options as raw JSON for the factory to decode into its own configuration type.
+type HandlerConfig struct {
+	APIType            string                   // APIType is the name the handler was registered under.
+	InstanceName       string                   // InstanceName is the name of the instance the handler targets.
+	OverrideBaseDomain string                   // OverrideBaseDomain is used to override the base domain for URL construction.
+	BaseURL            string                   // BaseURL, if set, replaces the scheme, host, port and path prefix used for resource endpoints.
+	AuthBaseURL        string                   // AuthBaseURL, if set, replaces the scheme, host, port and path prefix used for authentication endpoints.
+	TenantID           string                   // TenantID is the unique identifier for the tenant.
+	TenantName         string                   // TenantName is the name of the tenant.
+	Options            json.RawMessage          // Options holds handler specific settings, taken from Environment.APIOptions.
+	EndpointOverrides  endpointconfig.ConfigMap // EndpointOverrides are merged over the handler's built-in endpoint configuration.
+	Logger             logger.Logger            // Logger is the structured logger used for logging.
+}
+
+// DecodeOptions unmarshals the handler specific options into v. Empty options leave v untouched.
+func (c HandlerConfig) DecodeOptions(v interface{}) error {
+	if len(c.Options) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(c.Options, v); err != nil {
+		return fmt.Errorf("failed to decode options for api handler %q: %w", c.APIType, err)
+	}
+	return nil
+}
+
+// Factory creates an APIHandler from the supplied configuration.
+type Factory func(config HandlerConfig) (APIHandler, error)
+
+var (
+	registryLock sync.RWMutex
+	registry     = make(map[string]Factory)
+)
+
+// Register makes an API handler factory available under the given name, so that it can be selected
+// through Environment.APIType. It is intended to be called from the init function of the package
+// providing the handler. Register panics if the name is empty, the factory is nil or the name is
+// already registered.
+func Register(name string, factory Factory) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if name == "" {
+		panic("apihandler: Register called with an empty name")
+	}
+	if factory == nil {
+		panic("apihandler: Register factory is nil for " + name)
+	}
+	if _, exists := registry[name]; exists {
+		panic("apihandler: Register called twice for " + name)
+	}
+	registry[name] = factory
+}
+
+// RegisteredAPITypes returns the sorted names of all registered API handlers.
+func RegisteredAPITypes() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewAPIHandler loads the API handler registered under config.APIType.
+func NewAPIHandler(config HandlerConfig) (APIHandler, error) {
+	log := config.Logger
+
+	registryLock.RLock()
+	factory, ok := registry[config.APIType]
+	registryLock.RUnlock()
+
+	if !ok {
+		return nil, log.Error("Unsupported API type", zap.String("APIType", config.APIType), zap.Strings("RegisteredAPITypes", RegisteredAPITypes()))
+	}
+
+	apiHandler, err := factory(config)
+	if err != nil {
+		log.Error("Failed to create API handler", zap.String("APIType", config.APIType), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("API handler loaded successfully", zap.String("APIType", config.APIType), zap.String("InstanceName", config.InstanceName), zap.String("TenantID", config.TenantID), zap.String("TenantName", config.TenantName))
+	return apiHandler, nil
+}
+
+// init registers the API handlers that ship with the library.
+func init() {
+	Register("jamfpro", func(config HandlerConfig) (APIHandler, error) {
+		return &jamfpro.JamfAPIHandler{
+			Logger:             config.Logger,
+			InstanceName:       config.InstanceName, // Used for constructing both jamf pro resource and auth endpoints
+			OverrideBaseDomain: config.OverrideBaseDomain,
+			BaseURL:            config.BaseURL,
+			AuthBaseURL:        config.AuthBaseURL,
+			Endpoints:          jamfpro.DefaultEndpoints().Merge(config.EndpointOverrides),
+		}, nil
+	})
+
+	Register("msgraph", func(config HandlerConfig) (APIHandler, error) {
+		var options struct {
+			Cloud      string   `json:"Cloud"`      // National cloud, see msgraph.LookupCloud
+			APIVersion string   `json:"APIVersion"` // Default Graph API version, "v1.0" or "beta"
+			Prefer     []string `json:"Prefer"`     // Preferences sent in the Prefer header
+		}
+		if err := config.DecodeOptions(&options); err != nil {
+			return nil, err
+		}
+		if _, err := msgraph.LookupCloud(options.Cloud); err != nil {
+			return nil, err
+		}
+		if err := msgraph.ValidateAPIVersion(options.APIVersion); err != nil {
+			return nil, err
+		}
+		return &msgraph.GraphAPIHandler{
+			Logger:             config.Logger,
+			TenantID:           config.TenantID, // Used for constructing the graph auth endpoint
+			TenantName:         config.TenantName,
+			Cloud:              options.Cloud,
+			APIVersion:         options.APIVersion,
+			Prefer:             options.Prefer,
+			OverrideBaseDomain: config.OverrideBaseDomain,
+			BaseURL:            config.BaseURL,
+			AuthBaseURL:        config.AuthBaseURL,
+			Endpoints:          msgraph.DefaultEndpoints().Merge(config.EndpointOverrides),
+		}, nil
+	})
+
+	Register("github", func(config HandlerConfig) (APIHandler, error) {
+		return &github.GitHubAPIHandler{
+			Logger:             config.Logger,
+			InstanceName:       config.InstanceName, // GitHub Enterprise Server hostname, or github.com
+			OverrideBaseDomain: config.OverrideBaseDomain,
+			BaseURL:            config.BaseURL,
+			AuthBaseURL:        config.AuthBaseURL,
+			Endpoints:          github.DefaultEndpoints().Merge(config.EndpointOverrides),
+		}, nil
+	})
+
+	Register("generic", func(config HandlerConfig) (APIHandler, error) {
+		genericConfig, err := generic.LoadConfig(config.Options)
+		if err != nil {
+			return nil, err
+		}
+		// Environment level URL overrides take precedence over the handler configuration
+		if config.BaseURL != "" {
+			genericConfig.BaseURL = config.BaseURL
+		}
+		if config.AuthBaseURL != "" {
+			genericConfig.AuthBaseURL = config.AuthBaseURL
+		}
+		genericConfig.Endpoints = genericConfig.Endpoints.Merge(config.EndpointOverrides)
+		if err := genericConfig.Validate(); err != nil {
+			return nil, err
+		}
+		return &generic.GenericAPIHandler{
+			Config:       genericConfig,
+			InstanceName: config.InstanceName,
+			TenantID:     config.TenantID,
+			TenantName:   config.TenantName,
+			Logger:       config.Logger,
+		}, nil
+	})
+}
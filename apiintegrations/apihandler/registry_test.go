@@ -0,0 +1,185 @@
+// apiintegrations/apihandler/registry_test.go
+package apihandler
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/generic"
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/github"
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/jamfpro"
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/msgraph"
+	"github.com/deploymenttheory/go-api-http-client/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// customAPIHandler is a stand-in for an API handler shipped from another module.
+type customAPIHandler struct {
+	APIHandler
+	Region string `json:"region"`
+}
+
+// newTestLogger returns a mock logger that accepts any log call.
+func newTestLogger() *mocklogger.MockLogger {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Error", mock.Anything).Return(errors.New("logged error")).Maybe()
+	mockLog.On("Error", mock.Anything, mock.Anything).Maybe()
+	return mockLog
+}
+
+// TestNewAPIHandlerBuiltIn tests that the handlers shipped with the library are registered.
+func TestNewAPIHandlerBuiltIn(t *testing.T) {
+	tests := []struct {
+		apiType  string
+		wantType interface{}
+	}{
+		{"jamfpro", &jamfpro.JamfAPIHandler{}},
+		{"msgraph", &msgraph.GraphAPIHandler{}},
+		{"github", &github.GitHubAPIHandler{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.apiType, func(t *testing.T) {
+			handler, err := NewAPIHandler(HandlerConfig{APIType: tt.apiType, InstanceName: "instance", Logger: newTestLogger()})
+			require.NoError(t, err)
+			assert.IsType(t, tt.wantType, handler)
+		})
+	}
+}
+
+// TestNewAPIHandlerUnsupported tests that an unknown API type returns an error.
+func TestNewAPIHandlerUnsupported(t *testing.T) {
+	handler, err := NewAPIHandler(HandlerConfig{APIType: "unknown", Logger: newTestLogger()})
+	assert.Error(t, err)
+	assert.Nil(t, handler)
+}
+
+// TestRegister tests that a registered factory is selected by name and receives the handler options.
+func TestRegister(t *testing.T) {
+	Register("test-custom", func(config HandlerConfig) (APIHandler, error) {
+		handler := &customAPIHandler{}
+		if err := config.DecodeOptions(handler); err != nil {
+			return nil, err
+		}
+		return handler, nil
+	})
+
+	assert.Contains(t, RegisteredAPITypes(), "test-custom")
+
+	handler, err := NewAPIHandler(HandlerConfig{APIType: "test-custom", Options: json.RawMessage(`{"region":"eu"}`), Logger: newTestLogger()})
+	require.NoError(t, err)
+	assert.Equal(t, "eu", handler.(*customAPIHandler).Region)
+
+	_, err = NewAPIHandler(HandlerConfig{APIType: "test-custom", Options: json.RawMessage(`{"region":1}`), Logger: newTestLogger()})
+	assert.Error(t, err)
+
+	assert.Panics(t, func() {
+		Register("test-custom", func(config HandlerConfig) (APIHandler, error) { return nil, nil })
+	})
+}
+
+// TestNewAPIHandlerGeneric tests that the generic handler is configured from the handler options.
+func TestNewAPIHandlerGeneric(t *testing.T) {
+	options := json.RawMessage(`{"base_url": "https://{instance}.example.com", "auth_modes": ["oauth2"], "oauth_token_endpoint": "/oauth/token"}`)
+	handler, err := NewAPIHandler(HandlerConfig{APIType: "generic", InstanceName: "acme", Options: options, Logger: newTestLogger()})
+	require.NoError(t, err)
+	require.IsType(t, &generic.GenericAPIHandler{}, handler)
+	assert.Equal(t, "/oauth/token", handler.GetOAuthTokenEndpoint())
+	assert.True(t, handler.GetAPIOAuthAuthenticationSupportStatus())
+
+	_, err = NewAPIHandler(HandlerConfig{APIType: "generic", Logger: newTestLogger()})
+	assert.Error(t, err, "generic handler requires a base URL")
+}
+
+// TestNewAPIHandlerURLOverrides tests that the base domain and base URL overrides reach the built-in handlers.
+func TestNewAPIHandlerURLOverrides(t *testing.T) {
+	mockLog := newTestLogger()
+	mockLog.On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	tests := []struct {
+		name        string
+		config      HandlerConfig
+		expectedURL string
+	}{
+		{"Jamf Pro override base domain", HandlerConfig{APIType: "jamfpro", InstanceName: "acme", OverrideBaseDomain: ".jamf.corp"}, "https://acme.jamf.corp/api/v1/auth/token"},
+		{"Jamf Pro base URL", HandlerConfig{APIType: "jamfpro", BaseURL: "https://jamf.corp:8443/"}, "https://jamf.corp:8443/api/v1/auth/token"},
+		{"Jamf Pro auth base URL", HandlerConfig{APIType: "jamfpro", BaseURL: "https://jamf.corp:8443", AuthBaseURL: "http://127.0.0.1:9000"}, "http://127.0.0.1:9000/api/v1/auth/token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Logger = mockLog
+			handler, err := NewAPIHandler(tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedURL, handler.ConstructAPIAuthEndpoint("/api/v1/auth/token", mockLog))
+		})
+	}
+
+	options := json.RawMessage(`{"auth_modes": ["oauth2"], "oauth_token_endpoint": "/oauth/token"}`)
+	handler, err := NewAPIHandler(HandlerConfig{APIType: "generic", BaseURL: "http://127.0.0.1:9000", Options: options, Logger: mockLog})
+	require.NoError(t, err, "an environment base URL satisfies the generic handler's base_url")
+	assert.Equal(t, "http://127.0.0.1:9000/items", handler.ConstructAPIResourceEndpoint("/items", mockLog))
+}
+
+// TestNewAPIHandlerGraphCloud tests that the Microsoft Graph national cloud is taken from the handler options.
+func TestNewAPIHandlerGraphCloud(t *testing.T) {
+	handler, err := NewAPIHandler(HandlerConfig{APIType: "msgraph", Options: json.RawMessage(`{"Cloud":"usgovhigh"}`), Logger: newTestLogger()})
+	require.NoError(t, err)
+	assert.Equal(t, "https://graph.microsoft.us/.default", handler.GetOAuthTokenScope())
+
+	_, err = NewAPIHandler(HandlerConfig{APIType: "msgraph", Options: json.RawMessage(`{"Cloud":"moon"}`), Logger: newTestLogger()})
+	assert.Error(t, err)
+}
+
+// TestNewAPIHandlerEndpointOverrides tests that endpoint overrides are merged with the embedded configuration.
+func TestNewAPIHandlerEndpointOverrides(t *testing.T) {
+	mockLog := newTestLogger()
+	mockLog.On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	apiHandler, err := NewAPIHandler(HandlerConfig{
+		APIType:      "jamfpro",
+		InstanceName: "acme",
+		EndpointOverrides: jamfpro.ConfigMap{
+			"/api/v1/computers-inventory/{id}/attachments": {Accept: "application/octet-stream"},
+		},
+		Logger: mockLog,
+	})
+	require.NoError(t, err)
+
+	headers := apiHandler.GetAPIRequestHeaders("/api/v1/computers-inventory/12/attachments/3")
+	assert.Equal(t, "application/octet-stream", headers["Accept"])
+	assert.Equal(t, "", headers["Content-Type"])
+
+	// Embedded rules are kept alongside the overrides
+	assert.Equal(t, "text/csv", apiHandler.GetAPIRequestHeaders("/api/v2/inventory-preload/csv-template")["Accept"])
+}
+
+// TestBuiltInErrorResponseParsers tests that the built-in handlers parse their API's error bodies.
+func TestBuiltInErrorResponseParsers(t *testing.T) {
+	for _, apiType := range []string{"jamfpro", "msgraph", "github"} {
+		t.Run(apiType, func(t *testing.T) {
+			apiHandler, err := NewAPIHandler(HandlerConfig{APIType: apiType, InstanceName: "acme", TenantID: "tenant", Logger: newTestLogger()})
+			require.NoError(t, err)
+			assert.Implements(t, (*ErrorResponseParser)(nil), apiHandler)
+		})
+	}
+}
+
+// TestBuiltInConcurrencyDefaults tests that the Jamf Pro and Graph handlers supply valid concurrency defaults.
+func TestBuiltInConcurrencyDefaults(t *testing.T) {
+	for apiType, maxConcurrency := range map[string]int{"jamfpro": jamfpro.MaxConcurrency, "msgraph": msgraph.MaxConcurrency} {
+		t.Run(apiType, func(t *testing.T) {
+			apiHandler, err := NewAPIHandler(HandlerConfig{APIType: apiType, InstanceName: "acme", TenantID: "tenant", Logger: newTestLogger()})
+			require.NoError(t, err)
+			require.Implements(t, (*ConcurrencyDefaultsProvider)(nil), apiHandler)
+
+			defaults := apiHandler.(ConcurrencyDefaultsProvider).ConcurrencyDefaults()
+			assert.NoError(t, defaults.Validate())
+			assert.Equal(t, maxConcurrency, defaults.MaxConcurrency)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// apiintegrations/endpointconfig/endpointconfig.go
+/* Package endpointconfig holds the per-endpoint Accept and Content-Type exceptions shared by the API handlers.
+Rules are keyed by path pattern. A pattern matches any endpoint it is a prefix of on whole path segments, so
+"/api/v1/computers" matches "/api/v1/computers/12" but not "/api/v1/computers-inventory", and may contain
+placeholders such as {id} that match exactly one non-empty path segment, e.g. "/api/v1/computers/{id}/detail". */
+package endpointconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/deploymenttheory/go-api-http-client/helpers"
+)
+
+// EndpointConfig is a struct that holds configuration details for a specific API endpoint.
+// It includes what type of content it can accept and what content type it should send.
+type EndpointConfig struct {
+	Accept      string  `json:"accept"`       // Accept specifies the MIME type the endpoint can handle in responses.
+	ContentType *string `json:"content_type"` // ContentType, if not nil, specifies the MIME type to set for requests sent to the endpoint. A pointer is used to distinguish between a missing field and an empty string.
+}
+
+// ConfigMap is a map that associates endpoint URL patterns with their corresponding configurations.
+// The map's keys are strings that identify the endpoint, and the values are EndpointConfig structs
+// that hold the configuration for that endpoint.
+type ConfigMap map[string]EndpointConfig
+
+// Match returns the most specific rule matching the endpoint. The query string is ignored. When several
+// patterns match, the one matching the longest part of the path wins; on a tie a literal segment beats a
+// placeholder, and any remaining tie is broken by comparing the patterns, so the result never depends on
+// map iteration order.
+func (m ConfigMap) Match(endpoint string) (pattern string, config EndpointConfig, ok bool) {
+	patterns := make([]string, 0, len(m))
+	for key := range m {
+		patterns = append(patterns, key)
+	}
+	if pattern, ok = BestMatch(patterns, endpoint); ok {
+		config = m[pattern]
+	}
+	return pattern, config, ok
+}
+
+// BestMatch returns the most specific of patterns matching the endpoint, using the same rules as Match.
+// It lets other per-endpoint settings be keyed by the same path patterns.
+func BestMatch(patterns []string, endpoint string) (pattern string, ok bool) {
+	path, _, _ := strings.Cut(endpoint, "?")
+
+	bestMatched, bestLiteral := -1, -1
+	for _, candidate := range patterns {
+		matched, literal, matches := matchPattern(candidate, path)
+		if !matches {
+			continue
+		}
+		if matched > bestMatched ||
+			(matched == bestMatched && literal > bestLiteral) ||
+			(matched == bestMatched && literal == bestLiteral && candidate < pattern) {
+			pattern, ok = candidate, true
+			bestMatched, bestLiteral = matched, literal
+		}
+	}
+	return pattern, ok
+}
+
+// Merge returns a new ConfigMap holding the rules of m with those of overrides added. An override
+// with the same pattern as an existing rule replaces it entirely.
+func (m ConfigMap) Merge(overrides ConfigMap) ConfigMap {
+	merged := make(ConfigMap, len(m)+len(overrides))
+	for key, config := range m {
+		merged[key] = config
+	}
+	for key, config := range overrides {
+		merged[key] = config
+	}
+	return merged
+}
+
+// LoadFromFile reads a ConfigMap from a JSON file in the same format as the embedded exceptions
+// configuration of the API handlers.
+func LoadFromFile(filePath string) (ConfigMap, error) {
+	file, err := helpers.SafeOpenFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open endpoint configuration file: %w", err)
+	}
+	defer file.Close()
+
+	var configMap ConfigMap
+	if err := json.NewDecoder(file).Decode(&configMap); err != nil {
+		return nil, fmt.Errorf("failed to decode endpoint configuration file %s: %w", filePath, err)
+	}
+	return configMap, nil
+}
+
+// matchPattern reports whether pattern is a prefix of path ending on a segment boundary, with each {placeholder}
+// in the pattern matching one non-empty path segment. It returns the number of path characters matched and the number of literal
+// pattern characters they were matched against.
+func matchPattern(pattern, path string) (matched int, literal int, ok bool) {
+	for pattern != "" {
+		open := strings.IndexByte(pattern, '{')
+		closing := strings.IndexByte(pattern, '}')
+		if open < 0 || closing < open {
+			if !strings.HasPrefix(path, pattern) || !segmentBoundary(pattern, path[len(pattern):]) {
+				return 0, 0, false
+			}
+			return matched + len(pattern), literal + len(pattern), true
+		}
+
+		prefix := pattern[:open]
+		if !strings.HasPrefix(path, prefix) {
+			return 0, 0, false
+		}
+		path = path[len(prefix):]
+
+		segment := strings.IndexByte(path, '/')
+		if segment < 0 {
+			segment = len(path)
+		}
+		if segment == 0 {
+			return 0, 0, false
+		}
+		path = path[segment:]
+
+		matched += len(prefix) + segment
+		literal += len(prefix)
+		pattern = pattern[closing+1:]
+	}
+	return matched, literal, true
+}
+
+// segmentBoundary reports whether a literal pattern ends on a path segment boundary when rest of the path follows it.
+func segmentBoundary(pattern, rest string) bool {
+	return rest == "" || rest[0] == '/' || rest[0] == '?' || strings.HasSuffix(pattern, "/")
+}
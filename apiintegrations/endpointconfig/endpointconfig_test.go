@@ -0,0 +1,117 @@
+// apiintegrations/endpointconfig/endpointconfig_test.go
+package endpointconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMatch tests that the most specific rule is chosen regardless of map order.
+func TestMatch(t *testing.T) {
+	configMap := ConfigMap{
+		"/api/v1/computers":                {Accept: "prefix"},
+		"/api/v1/computers/{id}":           {Accept: "template"},
+		"/api/v1/computers/{id}/detail":    {Accept: "template-detail"},
+		"/api/v1/computers/inventory":      {Accept: "literal"},
+		"/api/v1/computers/inventory/{id}": {Accept: "literal-template"},
+		"/api/v1/icon/download/":           {Accept: "image/*"},
+	}
+
+	tests := []struct {
+		endpoint string
+		expected string
+	}{
+		{"/api/v1/computers", "prefix"},
+		{"/api/v1/computers/", "prefix"},
+		{"/api/v1/computers/12", "template"},
+		{"/api/v1/computers/12/detail", "template-detail"},
+		{"/api/v1/computers/inventory", "literal"},
+		{"/api/v1/computers/inventory?page=1", "literal"},
+		{"/api/v1/computers/inventory/12", "literal-template"},
+		{"/api/v1/computers/inventory/detail", "literal-template"},
+		{"/api/v1/icon/download/3", "image/*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				_, config, ok := configMap.Match(tt.endpoint)
+				require.True(t, ok)
+				assert.Equal(t, tt.expected, config.Accept)
+			}
+		})
+	}
+
+	_, _, ok := configMap.Match("/api/v2/mobile-devices")
+	assert.False(t, ok)
+}
+
+// TestMatchWholeSegments tests that a pattern does not match endpoints whose last segment only starts with it.
+func TestMatchWholeSegments(t *testing.T) {
+	configMap := ConfigMap{
+		"/api/v1/computers":           {Accept: "computers"},
+		"/api/v1/computers-inventory": {Accept: "inventory"},
+		"/api/v1/computers/{id}/det":  {Accept: "detail"},
+	}
+
+	tests := []struct {
+		endpoint string
+		expected string
+	}{
+		{"/api/v1/computers", "computers"},
+		{"/api/v1/computers/12", "computers"},
+		{"/api/v1/computers-inventory", "inventory"},
+		{"/api/v1/computers-inventory/12?section=GENERAL", "inventory"},
+		{"/api/v1/computers/12/det", "detail"},
+		{"/api/v1/computers/12/detail", "computers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			_, config, ok := configMap.Match(tt.endpoint)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, config.Accept)
+		})
+	}
+
+	_, _, ok := ConfigMap{"/api/v1/computers": {}}.Match("/api/v1/computers-inventory")
+	assert.False(t, ok)
+}
+
+// TestMatchTie tests that patterns matching equally are ordered by the pattern itself.
+func TestMatchTie(t *testing.T) {
+	configMap := ConfigMap{
+		"/api/{version}/users": {Accept: "b"},
+		"/api/{v}/users":       {Accept: "a"},
+	}
+	for i := 0; i < 20; i++ {
+		pattern, _, ok := configMap.Match("/api/v1/users")
+		require.True(t, ok)
+		assert.Equal(t, "/api/{version}/users", pattern)
+	}
+}
+
+// TestMerge tests that overrides replace and extend the base rules without modifying them.
+func TestMerge(t *testing.T) {
+	base := ConfigMap{"/a": {Accept: "base-a"}, "/b": {Accept: "base-b"}}
+	merged := base.Merge(ConfigMap{"/b": {Accept: "override-b"}, "/c": {Accept: "override-c"}})
+
+	assert.Equal(t, ConfigMap{"/a": {Accept: "base-a"}, "/b": {Accept: "override-b"}, "/c": {Accept: "override-c"}}, merged)
+	assert.Equal(t, "base-b", base["/b"].Accept)
+}
+
+// TestLoadFromFile tests reading an override file.
+func TestLoadFromFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "overrides.json")
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"/api/v1/reports/{id}/csv": {"accept": "text/csv", "content_type": null}}`), 0600))
+
+	configMap, err := LoadFromFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, ConfigMap{"/api/v1/reports/{id}/csv": {Accept: "text/csv"}}, configMap)
+
+	_, err = LoadFromFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,142 @@
+// apiintegrations/generic/generic_api_config.go
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/endpointconfig"
+	"github.com/deploymenttheory/go-api-http-client/helpers"
+)
+
+// Supported authentication modes for the auth_modes setting.
+const (
+	AuthModeBasic     = "basicauth"  // AuthModeBasic: username and password exchanged for a bearer token.
+	AuthModeOAuth     = "oauth2"     // AuthModeOAuth: OAuth client credentials.
+	AuthModeOAuthCert = "oauth2cert" // AuthModeOAuthCert: OAuth with a client certificate.
+)
+
+// Config describes a REST API handled by the GenericAPIHandler. It is read from Environment.APIOptions, and
+// optionally from the file named by ConfigFile, whose values are overridden by any inline options.
+type Config struct {
+	ConfigFile              string              `json:"config_file,omitempty"`               // ConfigFile is an optional path to a JSON file holding the rest of the configuration.
+	Name                    string              `json:"name,omitempty"`                      // Name is the API name used in log messages.
+	BaseURL                 string              `json:"base_url"`                            // BaseURL is the resource URL template, e.g. "https://{instance}.example.com/api".
+	AuthBaseURL             string              `json:"auth_base_url,omitempty"`             // AuthBaseURL is the authentication URL template; BaseURL is used when empty.
+	BearerTokenEndpoint     string              `json:"bearer_token_endpoint,omitempty"`     // BearerTokenEndpoint is the path used to exchange basic credentials for a token.
+	TokenRefreshEndpoint    string              `json:"token_refresh_endpoint,omitempty"`    // TokenRefreshEndpoint is the path used to refresh a bearer token.
+	TokenInvalidateEndpoint string              `json:"token_invalidate_endpoint,omitempty"` // TokenInvalidateEndpoint is the path used to invalidate a bearer token.
+	OAuthTokenEndpoint      string              `json:"oauth_token_endpoint,omitempty"`      // OAuthTokenEndpoint is the path used to obtain an OAuth token.
+	OAuthTokenScope         string              `json:"oauth_token_scope,omitempty"`         // OAuthTokenScope is the scope requested with the OAuth token.
+	TokenResponse           TokenResponseConfig `json:"token_response,omitempty"`            // TokenResponse names the fields of the token endpoint response.
+	ContentType             string              `json:"content_type,omitempty"`              // ContentType is the default request Content-Type; "application/json" when empty.
+	Accept                  string              `json:"accept,omitempty"`                    // Accept is the default Accept header; "application/json" when empty.
+	UserAgent               string              `json:"user_agent,omitempty"`                // UserAgent is sent with every request; "go-api-http-client-generic-handler" when empty.
+	Headers                 map[string]string   `json:"headers,omitempty"`                   // Headers are additional headers sent with every request.
+	Endpoints               ConfigMap           `json:"endpoints,omitempty"`                 // Endpoints holds per-endpoint Accept and Content-Type exceptions keyed by path pattern.
+	AuthModes               []string            `json:"auth_modes,omitempty"`                // AuthModes lists the supported authentication modes, at least one of "basicauth" and "oauth2".
+}
+
+// TokenResponseConfig names the fields holding the token and its expiry in a token endpoint response.
+// Nested fields are addressed with dots, e.g. "data.access_token". Expiry is read from ExpiresField as an
+// RFC 3339 timestamp or Unix seconds, or from ExpiresInField as a number of seconds. When no field is set, standard
+// OAuth 2.0 responses are read from access_token and expires_in, and other responses from token and expires.
+type TokenResponseConfig struct {
+	TokenField     string `json:"token_field,omitempty"`      // TokenField is the field holding the token; "token" when empty.
+	ExpiresField   string `json:"expires_field,omitempty"`    // ExpiresField is the field holding the absolute expiry time.
+	ExpiresInField string `json:"expires_in_field,omitempty"` // ExpiresInField is the field holding the lifetime in seconds.
+}
+
+// EndpointConfig holds the Accept and Content-Type exceptions for an API endpoint.
+type EndpointConfig = endpointconfig.EndpointConfig
+
+// ConfigMap associates endpoint path patterns with their configurations. See endpointconfig.ConfigMap.Match
+// for how patterns are matched.
+type ConfigMap = endpointconfig.ConfigMap
+
+// LoadConfig builds a Config from raw JSON options. When the options name a config_file, the file is read
+// first and the inline options are applied on top of it. The result is not validated, so that callers can
+// apply further overrides before calling Validate.
+func LoadConfig(options json.RawMessage) (Config, error) {
+	var config Config
+	if len(options) > 0 {
+		if err := json.Unmarshal(options, &config); err != nil {
+			return Config{}, fmt.Errorf("failed to decode generic api handler options: %w", err)
+		}
+	}
+
+	if config.ConfigFile != "" {
+		fileConfig, err := LoadConfigFromFile(config.ConfigFile)
+		if err != nil {
+			return Config{}, err
+		}
+		if err := json.Unmarshal(options, &fileConfig); err != nil {
+			return Config{}, fmt.Errorf("failed to decode generic api handler options: %w", err)
+		}
+		config = fileConfig
+	}
+
+	return config, nil
+}
+
+// LoadConfigFromFile reads a Config from a JSON file.
+func LoadConfigFromFile(filePath string) (Config, error) {
+	file, err := helpers.SafeOpenFile(filePath)
+	if err != nil {
+		return Config{}, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read generic api handler configuration file: %s, error: %w", filePath, err)
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return Config{}, fmt.Errorf("failed to decode generic api handler configuration file: %s, error: %w", filePath, err)
+	}
+
+	return config, nil
+}
+
+// Validate checks that the configuration describes a usable API.
+func (c Config) Validate() error {
+	if c.BaseURL == "" {
+		return errors.New("generic api handler configuration requires base_url")
+	}
+
+	if len(c.AuthModes) == 0 {
+		return errors.New("generic api handler configuration requires at least one auth mode in auth_modes")
+	}
+	for _, mode := range c.AuthModes {
+		switch mode {
+		case AuthModeBasic, AuthModeOAuth:
+		case AuthModeOAuthCert:
+			return fmt.Errorf("generic api handler configuration has auth mode %q, but client certificate authentication is not implemented", mode)
+		default:
+			return fmt.Errorf("generic api handler configuration has unsupported auth mode: %q", mode)
+		}
+	}
+
+	if c.supportsAuthMode(AuthModeBasic) && c.BearerTokenEndpoint == "" {
+		return errors.New("generic api handler configuration requires bearer_token_endpoint for basicauth")
+	}
+	if c.supportsAuthMode(AuthModeOAuth) && c.OAuthTokenEndpoint == "" {
+		return errors.New("generic api handler configuration requires oauth_token_endpoint for oauth2")
+	}
+
+	return nil
+}
+
+// supportsAuthMode reports whether the given authentication mode is listed in AuthModes.
+func (c Config) supportsAuthMode(mode string) bool {
+	for _, m := range c.AuthModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
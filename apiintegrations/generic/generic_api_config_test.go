@@ -0,0 +1,55 @@
+// apiintegrations/generic/generic_api_config_test.go
+package generic
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadConfigFromOptionsAndFile tests that inline options override the configuration file they reference.
+func TestLoadConfigFromOptionsAndFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "vendor.json")
+	fileConfig := `{"name": "vendor", "base_url": "https://{instance}.vendor.com", "bearer_token_endpoint": "/auth/token", "auth_modes": ["basicauth"]}`
+	require.NoError(t, os.WriteFile(configPath, []byte(fileConfig), 0600))
+
+	options, err := json.Marshal(map[string]string{"config_file": configPath, "base_url": "https://vendor.internal"})
+	require.NoError(t, err)
+
+	config, err := LoadConfig(options)
+	require.NoError(t, err)
+	assert.Equal(t, "vendor", config.Name)
+	assert.Equal(t, "https://vendor.internal", config.BaseURL)
+	assert.Equal(t, "/auth/token", config.BearerTokenEndpoint)
+}
+
+// TestConfigValidate tests the Validate function.
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"Valid", Config{BaseURL: "https://api.example.com", AuthModes: []string{"oauth2"}, OAuthTokenEndpoint: "/token"}, false},
+		{"Missing base URL", Config{}, true},
+		{"Unsupported auth mode", Config{BaseURL: "https://api.example.com", AuthModes: []string{"kerberos"}}, true},
+		{"Basic auth without token endpoint", Config{BaseURL: "https://api.example.com", AuthModes: []string{"basicauth"}}, true},
+		{"No auth modes", Config{BaseURL: "https://api.example.com", OAuthTokenEndpoint: "/token"}, true},
+		{"Client certificate auth mode", Config{BaseURL: "https://api.example.com", AuthModes: []string{"oauth2cert"}, OAuthTokenEndpoint: "/token"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+// apiintegrations/generic/generic_api_handler.go
+package generic
+
+import "github.com/deploymenttheory/go-api-http-client/logger"
+
+// GenericAPIHandler implements the APIHandler interface for REST APIs whose behaviour is described entirely by a
+// Config, such as the base URL, token endpoints, token response fields, headers and per-endpoint exceptions.
+type GenericAPIHandler struct {
+	Config       Config        // Config describes the API the handler talks to.
+	InstanceName string        // InstanceName replaces the {instance} placeholder in the base URL templates.
+	TenantID     string        // TenantID replaces the {tenant_id} placeholder in the base URL templates.
+	TenantName   string        // TenantName replaces the {tenant_name} placeholder in the base URL templates.
+	Logger       logger.Logger // Logger is the structured logger used for logging.
+}
@@ -0,0 +1,67 @@
+// apiintegrations/generic/generic_api_handler_constants.go
+package generic
+
+// Default values used when the configuration leaves a setting empty.
+const (
+	APIName            = "generic"                            // APIName: represents the name of the API when the configuration does not name it.
+	DefaultContentType = "application/json"                   // DefaultContentType: The Content-Type used when none is configured.
+	DefaultAccept      = "application/json"                   // DefaultAccept: The Accept header used when none is configured.
+	DefaultUserAgent   = "go-api-http-client-generic-handler" // DefaultUserAgent: The User-Agent used when none is configured.
+	DefaultTokenField  = "token"                              // DefaultTokenField: The token response field holding the token when none is configured.
+
+	OAuthTokenField     = "access_token" // OAuthTokenField: The token field of a standard OAuth 2.0 token response.
+	OAuthExpiresInField = "expires_in"   // OAuthExpiresInField: The lifetime field of a standard OAuth 2.0 token response.
+)
+
+// apiName returns the configured API name, used in log messages.
+func (g *GenericAPIHandler) apiName() string {
+	if g.Config.Name != "" {
+		return g.Config.Name
+	}
+	return APIName
+}
+
+// GetDefaultBaseDomain returns the configured base URL template, as the generic handler has no fixed domain.
+func (g *GenericAPIHandler) GetDefaultBaseDomain() string {
+	return g.Config.BaseURL
+}
+
+// GetOAuthTokenEndpoint returns the endpoint for obtaining an OAuth token. Used for constructing API URLs for the http client.
+func (g *GenericAPIHandler) GetOAuthTokenEndpoint() string {
+	return g.Config.OAuthTokenEndpoint
+}
+
+// GetOAuthTokenScope returns the scope for the OAuth token scope
+func (g *GenericAPIHandler) GetOAuthTokenScope() string {
+	return g.Config.OAuthTokenScope
+}
+
+// GetBearerTokenEndpoint returns the endpoint for obtaining a bearer token. Used for constructing API URLs for the http client.
+func (g *GenericAPIHandler) GetBearerTokenEndpoint() string {
+	return g.Config.BearerTokenEndpoint
+}
+
+// GetTokenRefreshEndpoint returns the endpoint for refreshing an existing token. Used for constructing API URLs for the http client.
+func (g *GenericAPIHandler) GetTokenRefreshEndpoint() string {
+	return g.Config.TokenRefreshEndpoint
+}
+
+// GetTokenInvalidateEndpoint returns the endpoint for invalidating an active token. Used for constructing API URLs for the http client.
+func (g *GenericAPIHandler) GetTokenInvalidateEndpoint() string {
+	return g.Config.TokenInvalidateEndpoint
+}
+
+// GetAPIBearerTokenAuthenticationSupportStatus returns a boolean indicating if bearer token authentication is supported in the api handler.
+func (g *GenericAPIHandler) GetAPIBearerTokenAuthenticationSupportStatus() bool {
+	return g.Config.supportsAuthMode(AuthModeBasic)
+}
+
+// GetAPIOAuthAuthenticationSupportStatus returns a boolean indicating if OAuth authentication is supported in the api handler.
+func (g *GenericAPIHandler) GetAPIOAuthAuthenticationSupportStatus() bool {
+	return g.Config.supportsAuthMode(AuthModeOAuth)
+}
+
+// GetAPIOAuthWithCertAuthenticationSupportStatus returns a boolean indicating if OAuth with client certificate authentication is supported in the api handler.
+func (g *GenericAPIHandler) GetAPIOAuthWithCertAuthenticationSupportStatus() bool {
+	return g.Config.supportsAuthMode(AuthModeOAuthCert)
+}
@@ -0,0 +1,70 @@
+// apiintegrations/generic/generic_api_headers.go
+package generic
+
+import (
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"go.uber.org/zap"
+)
+
+// GetContentTypeHeader determines the appropriate Content-Type header for a given API endpoint.
+// It attempts to find a content type that matches the endpoint in the configured endpoints.
+// If a match is found and the content type is defined (not nil), it returns the specified content type.
+// If the endpoint does not match any configured endpoint, the configured default content type is used.
+func (g *GenericAPIHandler) GetContentTypeHeader(endpoint string, log logger.Logger) string {
+	if config, ok := g.endpointConfig(endpoint); ok {
+		if config.ContentType != nil {
+			g.Logger.Debug("Content-Type for endpoint found in configMap", zap.String("endpoint", endpoint), zap.String("content_type", *config.ContentType))
+			return *config.ContentType
+		}
+		g.Logger.Debug("Content-Type for endpoint is nil in configMap, handling as special case", zap.String("endpoint", endpoint))
+		// If a nil ContentType is an expected case, do not set Content-Type header.
+		return "" // Return empty to indicate no Content-Type should be set.
+	}
+
+	if g.Config.ContentType != "" {
+		return g.Config.ContentType
+	}
+	return DefaultContentType
+}
+
+// GetAcceptHeader returns the configured default Accept header.
+func (g *GenericAPIHandler) GetAcceptHeader() string {
+	if g.Config.Accept != "" {
+		return g.Config.Accept
+	}
+	return DefaultAccept
+}
+
+// getAcceptHeaderForEndpoint returns the Accept header configured for the endpoint, or the default Accept header.
+func (g *GenericAPIHandler) getAcceptHeaderForEndpoint(endpoint string) string {
+	if config, ok := g.endpointConfig(endpoint); ok && config.Accept != "" {
+		return config.Accept
+	}
+	return g.GetAcceptHeader()
+}
+
+// GetAPIRequestHeaders returns a map of standard headers required for making API requests,
+// together with any additional headers from the configuration.
+func (g *GenericAPIHandler) GetAPIRequestHeaders(endpoint string) map[string]string {
+	userAgent := g.Config.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	headers := map[string]string{
+		"Accept":        g.getAcceptHeaderForEndpoint(endpoint),     // Dynamically set based on the endpoint.
+		"Content-Type":  g.GetContentTypeHeader(endpoint, g.Logger), // Dynamically set based on the endpoint.
+		"Authorization": "",                                         // To be set by the client with the actual token.
+		"User-Agent":    userAgent,                                  // Taken from the configuration.
+	}
+	for name, value := range g.Config.Headers {
+		headers[name] = value
+	}
+	return headers
+}
+
+// endpointConfig returns the configured endpoint rule matching the endpoint.
+func (g *GenericAPIHandler) endpointConfig(endpoint string) (EndpointConfig, bool) {
+	_, config, ok := g.Config.Endpoints.Match(endpoint)
+	return config, ok
+}
@@ -0,0 +1,63 @@
+// apiintegrations/generic/generic_api_headers_test.go
+package generic
+
+import (
+	"testing"
+
+	"github.com/deploymenttheory/go-api-http-client/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestGetAPIRequestHeaders tests the GetAPIRequestHeaders function.
+func TestGetAPIRequestHeaders(t *testing.T) {
+	csv := "text/csv"
+	handler := GenericAPIHandler{
+		Config: Config{
+			BaseURL:     "https://api.example.com",
+			ContentType: "application/xml",
+			Accept:      "application/xml",
+			Headers:     map[string]string{"X-Api-Version": "2"},
+			Endpoints: ConfigMap{
+				"/export":   {Accept: "text/csv", ContentType: &csv},
+				"/download": {Accept: "application/octet-stream", ContentType: nil},
+			},
+		},
+		Logger: mocklogger.NewMockLogger(),
+	}
+	handler.Logger.(*mocklogger.MockLogger).On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	tests := []struct {
+		name            string
+		endpoint        string
+		expectedHeaders map[string]string
+	}{
+		{"Defaults", "/devices", map[string]string{
+			"Accept":        "application/xml",
+			"Content-Type":  "application/xml",
+			"Authorization": "",
+			"User-Agent":    "go-api-http-client-generic-handler",
+			"X-Api-Version": "2",
+		}},
+		{"Endpoint exception", "/export/devices", map[string]string{
+			"Accept":        "text/csv",
+			"Content-Type":  "text/csv",
+			"Authorization": "",
+			"User-Agent":    "go-api-http-client-generic-handler",
+			"X-Api-Version": "2",
+		}},
+		{"Endpoint without content type", "/download/1", map[string]string{
+			"Accept":        "application/octet-stream",
+			"Content-Type":  "",
+			"Authorization": "",
+			"User-Agent":    "go-api-http-client-generic-handler",
+			"X-Api-Version": "2",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedHeaders, handler.GetAPIRequestHeaders(tt.endpoint))
+		})
+	}
+}
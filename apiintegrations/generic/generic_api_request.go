@@ -0,0 +1,83 @@
+// apiintegrations/generic/generic_api_request.go
+package generic
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
+	"github.com/deploymenttheory/go-api-http-client/helpers"
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"go.uber.org/zap"
+)
+
+// MarshalRequest encodes the request body as XML when the endpoint's Content-Type is XML, and as JSON otherwise.
+func (g *GenericAPIHandler) MarshalRequest(body interface{}, method string, endpoint string, log logger.Logger) ([]byte, error) {
+	if strings.Contains(g.GetContentTypeHeader(endpoint, g.Logger), "xml") {
+		data, err := xml.Marshal(body)
+		if err != nil {
+			g.Logger.Error("Failed marshaling XML request", zap.Error(err))
+			return nil, err
+		}
+
+		if method == "POST" || method == "PUT" || method == "PATCH" {
+			g.Logger.Debug("XML Request Body", zap.String("Body", string(data)))
+		}
+		return data, nil
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		g.Logger.Error("Failed marshaling JSON request", zap.Error(err))
+		return nil, err
+	}
+
+	if method == "POST" || method == "PUT" || method == "PATCH" {
+		g.Logger.Debug("JSON Request Body", zap.String("Body", string(data)))
+	}
+
+	return data, nil
+}
+
+// MarshalMultipartRequest handles multipart form data encoding with secure file handling and returns the encoded body and content type.
+func (g *GenericAPIHandler) MarshalMultipartRequest(fields map[string]string, files map[string]string, log logger.Logger) ([]byte, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	// Add the simple fields to the form data
+	for field, value := range fields {
+		if err := writer.WriteField(field, value); err != nil {
+			return nil, "", err
+		}
+	}
+
+	// Add the files to the form data, using safeOpenFile to ensure secure file access
+	for formField, filePath := range files {
+		file, err := helpers.SafeOpenFile(filePath)
+		if err != nil {
+			log.Error("Failed to open file securely", zap.String("file", filePath), zap.Error(err))
+			return nil, "", err
+		}
+		defer file.Close()
+
+		part, err := writer.CreateFormFile(formField, filepath.Base(filePath))
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := io.Copy(part, file); err != nil {
+			return nil, "", err
+		}
+	}
+
+	// Close the writer to finish writing the multipart message
+	contentType := writer.FormDataContentType()
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return body.Bytes(), contentType, nil
+}
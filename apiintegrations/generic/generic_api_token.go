@@ -0,0 +1,120 @@
+// apiintegrations/generic/generic_api_token.go
+package generic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseTokenResponse extracts the token and its expiry from a token endpoint response using the field names
+// from the configuration. It implements apihandler.TokenResponseParser. When no token response fields are
+// configured, a standard OAuth 2.0 response holding access_token is read from access_token and expires_in, and
+// any other response from token and expires.
+func (g *GenericAPIHandler) ParseTokenResponse(body []byte) (string, time.Time, error) {
+	var response map[string]interface{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	if g.Config.TokenResponse == (TokenResponseConfig{}) {
+		if _, ok := response[OAuthTokenField]; ok {
+			return parseOAuthTokenResponse(response)
+		}
+	}
+
+	tokenField := g.Config.TokenResponse.TokenField
+	if tokenField == "" {
+		tokenField = DefaultTokenField
+	}
+
+	token, ok := lookupField(response, tokenField).(string)
+	if !ok || token == "" {
+		return "", time.Time{}, fmt.Errorf("token response has no token in field %q", tokenField)
+	}
+
+	expires, err := g.parseTokenExpiry(response)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expires, nil
+}
+
+// parseOAuthTokenResponse reads a standard OAuth 2.0 token response. expires_in is optional; without it the
+// expiry is zero, as the token does not expire.
+func parseOAuthTokenResponse(response map[string]interface{}) (string, time.Time, error) {
+	token, ok := response[OAuthTokenField].(string)
+	if !ok || token == "" {
+		return "", time.Time{}, fmt.Errorf("token response has no token in field %q", OAuthTokenField)
+	}
+
+	value, ok := response[OAuthExpiresInField]
+	if !ok {
+		return token, time.Time{}, nil
+	}
+	seconds, err := numberValue(value)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("token response field %q: %w", OAuthExpiresInField, err)
+	}
+	if seconds <= 0 {
+		return token, time.Time{}, nil
+	}
+	return token, time.Now().Add(time.Duration(seconds) * time.Second), nil
+}
+
+// parseTokenExpiry reads the token expiry from the configured expires or expires_in field.
+func (g *GenericAPIHandler) parseTokenExpiry(response map[string]interface{}) (time.Time, error) {
+	if field := g.Config.TokenResponse.ExpiresInField; field != "" {
+		seconds, err := numberValue(lookupField(response, field))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("token response field %q: %w", field, err)
+		}
+		return time.Now().Add(time.Duration(seconds) * time.Second), nil
+	}
+
+	field := g.Config.TokenResponse.ExpiresField
+	if field == "" {
+		field = "expires"
+	}
+
+	switch value := lookupField(response, field).(type) {
+	case string:
+		expires, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("token response field %q: %w", field, err)
+		}
+		return expires, nil
+	case float64:
+		return time.Unix(int64(value), 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("token response has no expiry in field %q", field)
+	}
+}
+
+// lookupField returns the value at a dotted field path within a decoded JSON object, or nil if absent.
+func lookupField(object map[string]interface{}, path string) interface{} {
+	var value interface{} = object
+	for _, key := range strings.Split(path, ".") {
+		current, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = current[key]
+	}
+	return value
+}
+
+// numberValue converts a decoded JSON number, or a number encoded as a string, to a float64.
+func numberValue(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("expected a number, got %T", value)
+	}
+}
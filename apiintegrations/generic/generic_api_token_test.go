@@ -0,0 +1,65 @@
+// apiintegrations/generic/generic_api_token_test.go
+package generic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseTokenResponse tests the ParseTokenResponse function with the supported token response layouts.
+func TestParseTokenResponse(t *testing.T) {
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		tokenResponse TokenResponseConfig
+		body          string
+		wantToken     string
+		wantExpires   time.Time
+		wantExpiresIn time.Duration
+	}{
+		{"Default fields", TokenResponseConfig{}, `{"token":"abc","expires":"2030-01-02T03:04:05Z"}`, "abc", expires, 0},
+		{"Nested fields", TokenResponseConfig{TokenField: "data.jwt", ExpiresField: "data.exp"}, `{"data":{"jwt":"abc","exp":1893553445}}`, "abc", expires, 0},
+		{"Expires in", TokenResponseConfig{TokenField: "access_token", ExpiresInField: "expires_in"}, `{"access_token":"abc","expires_in":3600}`, "abc", time.Time{}, time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := GenericAPIHandler{Config: Config{TokenResponse: tt.tokenResponse}}
+			token, gotExpires, err := handler.ParseTokenResponse([]byte(tt.body))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantToken, token)
+			if tt.wantExpiresIn > 0 {
+				assert.WithinDuration(t, time.Now().Add(tt.wantExpiresIn), gotExpires, 5*time.Second)
+			} else {
+				assert.True(t, tt.wantExpires.Equal(gotExpires))
+			}
+		})
+	}
+}
+
+// TestParseTokenResponseMissingToken tests that a response without the configured token field is rejected.
+func TestParseTokenResponseMissingToken(t *testing.T) {
+	handler := GenericAPIHandler{Config: Config{TokenResponse: TokenResponseConfig{TokenField: "access_token"}}}
+	_, _, err := handler.ParseTokenResponse([]byte(`{"token":"abc","expires_in":3600}`))
+	assert.Error(t, err)
+}
+
+// TestParseTokenResponseStandardOAuth tests that, without configured token response fields, a standard OAuth 2.0
+// response is read from access_token and expires_in, and that a missing expires_in yields no expiry.
+func TestParseTokenResponseStandardOAuth(t *testing.T) {
+	handler := GenericAPIHandler{Config: Config{AuthModes: []string{AuthModeOAuth}, OAuthTokenEndpoint: "/oauth/token"}}
+
+	token, expires, err := handler.ParseTokenResponse([]byte(`{"access_token":"abc","token_type":"Bearer","expires_in":3600}`))
+	require.NoError(t, err)
+	assert.Equal(t, "abc", token)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expires, 5*time.Second)
+
+	token, expires, err = handler.ParseTokenResponse([]byte(`{"access_token":"abc","token_type":"Bearer"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "abc", token)
+	assert.True(t, expires.IsZero())
+}
@@ -0,0 +1,41 @@
+// apiintegrations/generic/generic_api_url.go
+package generic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/deploymenttheory/go-api-http-client/helpers"
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"go.uber.org/zap"
+)
+
+// expandURLTemplate replaces the {instance}, {tenant_id} and {tenant_name} placeholders of a URL template.
+func (g *GenericAPIHandler) expandURLTemplate(template string) string {
+	replacer := strings.NewReplacer(
+		"{instance}", g.InstanceName,
+		"{tenant_id}", g.TenantID,
+		"{tenant_name}", g.TenantName,
+	)
+	return replacer.Replace(template)
+}
+
+// ConstructAPIResourceEndpoint constructs the full URL for an API resource endpoint path from the configured
+// base URL template and logs the URL.
+func (g *GenericAPIHandler) ConstructAPIResourceEndpoint(endpointPath string, log logger.Logger) string {
+	url := helpers.JoinURL(g.expandURLTemplate(g.Config.BaseURL), endpointPath)
+	g.Logger.Debug(fmt.Sprintf("Constructed %s API resource endpoint URL", g.apiName()), zap.String("URL", url))
+	return url
+}
+
+// ConstructAPIAuthEndpoint constructs the full URL for an API authentication endpoint path from the configured
+// auth base URL template, falling back to the base URL template, and logs the URL.
+func (g *GenericAPIHandler) ConstructAPIAuthEndpoint(endpointPath string, log logger.Logger) string {
+	template := g.Config.AuthBaseURL
+	if template == "" {
+		template = g.Config.BaseURL
+	}
+	url := helpers.JoinURL(g.expandURLTemplate(template), endpointPath)
+	g.Logger.Debug(fmt.Sprintf("Constructed %s API authentication URL", g.apiName()), zap.String("URL", url))
+	return url
+}
@@ -0,0 +1,66 @@
+// apiintegrations/generic/generic_api_url_test.go
+package generic
+
+import (
+	"testing"
+
+	"github.com/deploymenttheory/go-api-http-client/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestConstructAPIResourceEndpoint tests the ConstructAPIResourceEndpoint function.
+func TestConstructAPIResourceEndpoint(t *testing.T) {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Debug", mock.AnythingOfType("string"), mock.Anything).Once()
+
+	handler := GenericAPIHandler{
+		Config:       Config{BaseURL: "https://{instance}.example.com/api/"},
+		InstanceName: "acme",
+		Logger:       mockLog,
+	}
+
+	resultURL := handler.ConstructAPIResourceEndpoint("/v1/devices", mockLog)
+
+	assert.Equal(t, "https://acme.example.com/api/v1/devices", resultURL, "URL should match expected format")
+	mockLog.AssertExpectations(t)
+}
+
+// TestConstructAPIResourceEndpointSlashes tests that base URLs and endpoint paths are joined with a single slash.
+func TestConstructAPIResourceEndpointSlashes(t *testing.T) {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Debug", mock.AnythingOfType("string"), mock.Anything)
+
+	for _, baseURL := range []string{"https://api.example.com/v2", "https://api.example.com/v2/"} {
+		for _, endpointPath := range []string{"/devices", "devices"} {
+			handler := GenericAPIHandler{Config: Config{BaseURL: baseURL}, Logger: mockLog}
+			assert.Equal(t, "https://api.example.com/v2/devices", handler.ConstructAPIResourceEndpoint(endpointPath, mockLog), "base %q, path %q", baseURL, endpointPath)
+			assert.Equal(t, "https://api.example.com/v2/oauth/token", handler.ConstructAPIAuthEndpoint("oauth/token", mockLog), "base %q", baseURL)
+		}
+	}
+}
+
+// TestConstructAPIAuthEndpoint tests the ConstructAPIAuthEndpoint function.
+func TestConstructAPIAuthEndpoint(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedURL string
+	}{
+		{"Auth base URL", Config{BaseURL: "https://api.example.com", AuthBaseURL: "https://login.example.com/{tenant_id}"}, "https://login.example.com/tenant-1/oauth/token"},
+		{"Falls back to base URL", Config{BaseURL: "https://api.example.com"}, "https://api.example.com/oauth/token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLog := mocklogger.NewMockLogger()
+			mockLog.On("Debug", mock.AnythingOfType("string"), mock.Anything).Once()
+
+			handler := GenericAPIHandler{Config: tt.config, TenantID: "tenant-1", Logger: mockLog}
+			resultURL := handler.ConstructAPIAuthEndpoint("/oauth/token", mockLog)
+
+			assert.Equal(t, tt.expectedURL, resultURL, "URL should match expected format")
+			mockLog.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// apiintegrations/github/github_api_errors.go
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/deploymenttheory/go-api-http-client/response"
+)
+
+// GitHubErrorCause represents an entry of the errors array of a GitHub validation error. GitHub also
+// sends plain strings in this array, which are decoded into Message.
+type GitHubErrorCause struct {
+	Resource string `json:"resource,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// UnmarshalJSON decodes an error cause given either as an object or as a string.
+func (c *GitHubErrorCause) UnmarshalJSON(data []byte) error {
+	var message string
+	if err := json.Unmarshal(data, &message); err == nil {
+		*c = GitHubErrorCause{Message: message}
+		return nil
+	}
+	type cause GitHubErrorCause
+	return json.Unmarshal(data, (*cause)(c))
+}
+
+// GitHubErrorResponse represents the body of a GitHub REST API error response,
+// e.g. {"message":"Validation Failed","errors":[{"resource":"Issue","field":"title","code":"missing_field"}],"documentation_url":"..."}.
+type GitHubErrorResponse struct {
+	Message          string             `json:"message"`
+	Errors           []GitHubErrorCause `json:"errors,omitempty"`
+	DocumentationURL string             `json:"documentation_url,omitempty"`
+}
+
+// ParseErrorResponse fills the APIError from a GitHub error body, including the documentation link and the
+// individual validation errors. The request ID is taken from the X-GitHub-Request-Id header by the generic parsing.
+func (g *GitHubAPIHandler) ParseErrorResponse(resp *http.Response, body []byte, apiError *response.APIError) error {
+	var gitHubError GitHubErrorResponse
+	if err := json.Unmarshal(body, &gitHubError); err != nil || gitHubError.Message == "" {
+		return nil
+	}
+
+	apiError.Message = gitHubError.Message
+	apiError.DocumentationURL = gitHubError.DocumentationURL
+	for i, cause := range gitHubError.Errors {
+		if i == 0 {
+			apiError.Code = cause.Code
+			apiError.Target = cause.Field
+		}
+		apiError.Details = append(apiError.Details, cause.String())
+	}
+	return nil
+}
+
+// String describes the error cause, e.g. "Issue.title: missing_field".
+func (c GitHubErrorCause) String() string {
+	if c.Message != "" {
+		return c.Message
+	}
+	name := strings.Trim(c.Resource+"."+c.Field, ".")
+	if name == "" {
+		return c.Code
+	}
+	return name + ": " + c.Code
+}
@@ -0,0 +1,31 @@
+// apiintegrations/github/github_api_errors_test.go
+package github
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/deploymenttheory/go-api-http-client/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseErrorResponse tests that GitHub error bodies fill the message, documentation link and validation errors.
+func TestParseErrorResponse(t *testing.T) {
+	handler := &GitHubAPIHandler{}
+	body := `{"message":"Validation Failed","errors":[` +
+		`{"resource":"Issue","field":"title","code":"missing_field"},` +
+		`{"resource":"Label","code":"custom","message":"name is too long"},` +
+		`"Only one assignee is allowed"],` +
+		`"documentation_url":"https://docs.github.com/rest/issues/issues#create-an-issue","status":"422"}`
+
+	apiError := &response.APIError{StatusCode: http.StatusUnprocessableEntity, RequestID: "C0DE:1234"}
+	require.NoError(t, handler.ParseErrorResponse(&http.Response{}, []byte(body), apiError))
+
+	assert.Equal(t, "Validation Failed", apiError.Message)
+	assert.Equal(t, "https://docs.github.com/rest/issues/issues#create-an-issue", apiError.DocumentationURL)
+	assert.Equal(t, "missing_field", apiError.Code)
+	assert.Equal(t, "title", apiError.Target)
+	assert.Equal(t, []string{"Issue.title: missing_field", "name is too long", "Only one assignee is allowed"}, apiError.Details)
+	assert.Equal(t, "C0DE:1234", apiError.RequestID)
+}
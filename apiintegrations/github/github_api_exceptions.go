@@ -0,0 +1,48 @@
+// apiintegrations/github/github_api_exceptions.go
+package github
+
+import (
+	_ "embed"
+
+	"encoding/json"
+	"log"
+
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/endpointconfig"
+)
+
+// EndpointConfig holds the Accept and Content-Type exceptions for an API endpoint.
+type EndpointConfig = endpointconfig.EndpointConfig
+
+// ConfigMap associates endpoint path patterns with their configurations. See endpointconfig.ConfigMap.Match
+// for how patterns are matched.
+type ConfigMap = endpointconfig.ConfigMap
+
+// Variables
+var configMap ConfigMap
+
+// Embedded Resources
+//
+//go:embed github_api_exceptions_configuration.json
+var github_api_exceptions_configuration []byte
+
+// init is invoked automatically on package initialization and is responsible for
+// setting up the default state of the package by loading the api exceptions configuration.
+func init() {
+	// Load the default configuration from an embedded resource.
+	err := loadAPIExceptionsConfiguration()
+	if err != nil {
+		log.Fatalf("Error loading GitHub API exceptions configuration: %s", err)
+	}
+}
+
+// loadAPIExceptionsConfiguration reads and unmarshals the github_api_exceptions_configuration JSON data from an embedded file
+// into the configMap variable, which holds the exceptions configuration for endpoint-specific headers.
+func loadAPIExceptionsConfiguration() error {
+	// Unmarshal the embedded default configuration into the global configMap.
+	return json.Unmarshal(github_api_exceptions_configuration, &configMap)
+}
+
+// DefaultEndpoints returns a copy of the embedded endpoint configuration, for merging with user overrides.
+func DefaultEndpoints() ConfigMap {
+	return configMap.Merge(nil)
+}
@@ -1,11 +1,14 @@
-// github_api_handler.go
+// apiintegrations/github/github_api_handler.go
 package github
 
 import "github.com/deploymenttheory/go-api-http-client/logger"
 
-// GitHubHandler implements the APIHandler interface for the GitHub API.
+// GitHubAPIHandler implements the APIHandler interface for the GitHub API.
 type GitHubAPIHandler struct {
 	OverrideBaseDomain string        // OverrideBaseDomain is used to override the base domain for URL construction.
-	InstanceName       string        // InstanceName is the name of the GitHub instance.
+	InstanceName       string        // InstanceName is the hostname of a GitHub Enterprise Server instance; empty or "github.com" targets github.com.
+	BaseURL            string        // BaseURL, if set, replaces the scheme, host, port and path prefix used for resource endpoints.
+	AuthBaseURL        string        // AuthBaseURL, if set, replaces the scheme, host, port and path prefix used for authentication endpoints.
+	Endpoints          ConfigMap     // Endpoints, if set, replaces the embedded endpoint configuration; see DefaultEndpoints.
 	Logger             logger.Logger // Logger is the structured logger used for logging.
 }
@@ -1,17 +1,34 @@
-// github_handler_constants.go
+// apiintegrations/github/github_api_handler_constants.go
 package github
 
+import "time"
+
 // Endpoint constants represent the URL suffixes used for GitHub token interactions.
 const (
-	APIName                            = "github"                                       // APIName: represents the name of the API.
-	DefaultBaseDomain                  = "api.github.com"                               // DefaultBaseDomain: represents the base domain for the github instance.
-	OAuthTokenEndpoint                 = "github.com/login/oauth/access_token"          // OAuthTokenEndpoint: The endpoint to obtain an OAuth token.
-	BearerTokenEndpoint                = ""                                             // BearerTokenEndpoint: The endpoint to obtain a bearer token.
-	TokenRefreshEndpoint               = "github.com/login/oauth/access_token"          // TokenRefreshEndpoint: The endpoint to refresh an existing token.
-	TokenInvalidateEndpoint            = "api.github.com/applications/:client_id/token" // TokenInvalidateEndpoint: The endpoint to invalidate an active token.
-	BearerTokenAuthenticationSupport   = false                                          // BearerTokenAuthSuppport: A boolean to indicate if the API supports bearer token authentication.
-	OAuthAuthenticationSupport         = true                                           // OAuthAuthSuppport: A boolean to indicate if the API supports OAuth authentication.
-	OAuthWithCertAuthenticationSupport = true                                           // OAuthWithCertAuthSuppport: A boolean to indicate if the API supports OAuth with client certificate authentication.
+	APIName                            = "github"                          // APIName: represents the name of the API.
+	DefaultBaseDomain                  = "api.github.com"                  // DefaultBaseDomain: represents the base domain for the github.com REST API.
+	DefaultAuthDomain                  = "github.com"                      // DefaultAuthDomain: represents the domain serving github.com OAuth endpoints.
+	EnterpriseServerAPIPath            = "/api/v3"                         // EnterpriseServerAPIPath: The path prefix of the REST API on a GitHub Enterprise Server host.
+	OAuthTokenEndpoint                 = "/login/oauth/access_token"       // OAuthTokenEndpoint: The endpoint exchanging a refresh token for a user access token of a GitHub App.
+	OAuthTokenScope                    = ""                                // OAuthTokenScope: Not used for GitHub, permissions are granted to the app rather than requested per token.
+	BearerTokenEndpoint                = ""                                // BearerTokenEndpoint: The endpoint to obtain a bearer token.
+	TokenRefreshEndpoint               = ""                                // TokenRefreshEndpoint: Not used, OAuth tokens are renewed at OAuthTokenEndpoint with their refresh token.
+	TokenInvalidateEndpoint            = "/applications/{client_id}/token" // TokenInvalidateEndpoint: The endpoint to invalidate an active token.
+	BearerTokenAuthenticationSupport   = false                             // BearerTokenAuthSuppport: A boolean to indicate if the API supports bearer token authentication.
+	OAuthAuthenticationSupport         = true                              // OAuthAuthSuppport: Supported with the refresh token grant only, GitHub has no client credentials grant.
+	OAuthWithCertAuthenticationSupport = false                             // OAuthWithCertAuthSuppport: A boolean to indicate if the API supports OAuth with client certificate authentication.
+)
+
+// GitHub App constants represent the REST API paths and values used to authenticate as a GitHub App installation.
+const (
+	APIVersion                     = "2022-11-28"                          // APIVersion: The REST API version pinned through the X-GitHub-Api-Version header.
+	MediaType                      = "application/vnd.github+json"         // MediaType: The media type GitHub recommends for REST API requests.
+	AppInstallationTokenEndpoint   = "/app/installations/%d/access_tokens" // AppInstallationTokenEndpoint: Exchanges an app JWT for an installation access token, formatted with the installation ID.
+	OrgInstallationEndpoint        = "/orgs/%s/installation"               // OrgInstallationEndpoint: Looks up the app installation for an organization.
+	RepoInstallationEndpoint       = "/repos/%s/%s/installation"           // RepoInstallationEndpoint: Looks up the app installation for a repository, formatted with the owner and repository name.
+	UserInstallationEndpoint       = "/users/%s/installation"              // UserInstallationEndpoint: Looks up the app installation for a user account.
+	InstallationTokenLifetime      = 1 * time.Hour                         // InstallationTokenLifetime: The lifetime GitHub grants installation access tokens.
+	GitHubAppAuthenticationSupport = true                                  // GitHubAppAuthenticationSupport: A boolean to indicate if the API supports GitHub App installation authentication.
 )
 
 // GetDefaultBaseDomain returns the default base domain used for constructing API URLs to the http client.
@@ -24,6 +41,11 @@ func (g *GitHubAPIHandler) GetOAuthTokenEndpoint() string {
 	return OAuthTokenEndpoint
 }
 
+// GetOAuthTokenScope returns the scope for the OAuth token scope
+func (g *GitHubAPIHandler) GetOAuthTokenScope() string {
+	return OAuthTokenScope
+}
+
 // GetBearerTokenEndpoint returns the endpoint for obtaining a bearer token. Used for constructing API URLs for the http client.
 func (g *GitHubAPIHandler) GetBearerTokenEndpoint() string {
 	return BearerTokenEndpoint
@@ -53,3 +75,14 @@ func (g *GitHubAPIHandler) GetAPIOAuthAuthenticationSupportStatus() bool {
 func (g *GitHubAPIHandler) GetAPIOAuthWithCertAuthenticationSupportStatus() bool {
 	return OAuthWithCertAuthenticationSupport
 }
+
+// GetAPIGitHubAppAuthenticationSupportStatus returns a boolean indicating if GitHub App installation authentication is supported in the api handler.
+func (g *GitHubAPIHandler) GetAPIGitHubAppAuthenticationSupportStatus() bool {
+	return GitHubAppAuthenticationSupport
+}
+
+// RequiresOAuthRefreshToken reports that OAuth access tokens can only be obtained for a refresh token, as GitHub has
+// no client credentials grant.
+func (g *GitHubAPIHandler) RequiresOAuthRefreshToken() bool {
+	return true
+}
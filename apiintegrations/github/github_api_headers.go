@@ -0,0 +1,67 @@
+// apiintegrations/github/github_api_headers.go
+package github
+
+import (
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"go.uber.org/zap"
+)
+
+// GetContentTypeHeader determines the appropriate Content-Type header for a given API endpoint.
+// It attempts to find a content type that matches the endpoint in the endpoint configuration.
+// If a match is found and the content type is defined (not nil), it returns the specified content type.
+// If the endpoint does not match any of the predefined patterns, "application/json" is used as a fallback.
+// This method logs the decision process at various stages for debugging purposes.
+func (g *GitHubAPIHandler) GetContentTypeHeader(endpoint string, log logger.Logger) string {
+	// Dynamic lookup from configuration should be the first priority
+	if config, ok := g.endpointConfig(endpoint); ok {
+		if config.ContentType != nil {
+			g.Logger.Debug("Content-Type for endpoint found in configMap", zap.String("endpoint", endpoint), zap.String("content_type", *config.ContentType))
+			return *config.ContentType
+		}
+		g.Logger.Debug("Content-Type for endpoint is nil in configMap, handling as special case", zap.String("endpoint", endpoint))
+		// If a nil ContentType is an expected case, do not set Content-Type header.
+		return "" // Return empty to indicate no Content-Type should be set.
+	}
+
+	// Fallback to JSON if no other match is found.
+	g.Logger.Debug("Content-Type for endpoint not found in configMap or standard patterns, using default JSON", zap.String("endpoint", endpoint))
+	return "application/json"
+}
+
+// GetAcceptHeader returns the Accept header for GitHub REST API requests. GitHub recommends the
+// application/vnd.github+json media type, which returns JSON in the format of the pinned API version.
+func (g *GitHubAPIHandler) GetAcceptHeader() string {
+	return MediaType
+}
+
+// GetAcceptHeaderForEndpoint returns the Accept header for a given API endpoint. Endpoints that
+// return a different representation, such as rendered markdown, are configured in the endpoint configuration.
+func (g *GitHubAPIHandler) GetAcceptHeaderForEndpoint(endpoint string) string {
+	if config, ok := g.endpointConfig(endpoint); ok && config.Accept != "" {
+		return config.Accept
+	}
+	return g.GetAcceptHeader()
+}
+
+// GetAPIRequestHeaders returns a map of standard headers required for making API requests.
+func (g *GitHubAPIHandler) GetAPIRequestHeaders(endpoint string) map[string]string {
+	headers := map[string]string{
+		"Accept":               g.GetAcceptHeaderForEndpoint(endpoint),     // Dynamically set based on the endpoint.
+		"Content-Type":         g.GetContentTypeHeader(endpoint, g.Logger), // Dynamically set based on the endpoint.
+		"Authorization":        "",                                         // To be set by the client with the actual token.
+		"User-Agent":           "go-api-http-client-github-handler",        // GitHub rejects requests without a User-Agent.
+		"X-GitHub-Api-Version": APIVersion,                                 // Pins the REST API version the handler was written against.
+	}
+	return headers
+}
+
+// endpointConfig returns the endpoint configuration rule matching the endpoint. The handler's Endpoints
+// are used when set, otherwise the embedded configuration.
+func (g *GitHubAPIHandler) endpointConfig(endpoint string) (EndpointConfig, bool) {
+	endpoints := g.Endpoints
+	if endpoints == nil {
+		endpoints = configMap
+	}
+	_, config, ok := endpoints.Match(endpoint)
+	return config, ok
+}
@@ -0,0 +1,45 @@
+// apiintegrations/github/github_api_headers_test.go
+package github
+
+import (
+	"testing"
+
+	"github.com/deploymenttheory/go-api-http-client/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestGetAPIRequestHeaders tests the GetAPIRequestHeaders function.
+func TestGetAPIRequestHeaders(t *testing.T) {
+	handler := GitHubAPIHandler{Logger: mocklogger.NewMockLogger()}
+	endpoint := "/repos/octo/hello/issues"
+	handler.Logger.(*mocklogger.MockLogger).On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	expectedHeaders := map[string]string{
+		"Accept":               "application/vnd.github+json",
+		"Content-Type":         "application/json",
+		"Authorization":        "",
+		"User-Agent":           "go-api-http-client-github-handler",
+		"X-GitHub-Api-Version": "2022-11-28",
+	}
+
+	headers := handler.GetAPIRequestHeaders(endpoint)
+	assert.Equal(t, expectedHeaders, headers)
+	handler.Logger.(*mocklogger.MockLogger).AssertExpectations(t)
+}
+
+// TestGetAPIRequestHeadersConfiguredEndpoint tests that endpoint specific headers are taken from the configMap.
+func TestGetAPIRequestHeadersConfiguredEndpoint(t *testing.T) {
+	handler := GitHubAPIHandler{Logger: mocklogger.NewMockLogger()}
+	handler.Logger.(*mocklogger.MockLogger).On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	headers := handler.GetAPIRequestHeaders("/markdown/raw")
+	assert.Equal(t, "text/html", headers["Accept"])
+	assert.Equal(t, "text/plain", headers["Content-Type"])
+}
+
+// TestGetAcceptHeader tests the GetAcceptHeader function.
+func TestGetAcceptHeader(t *testing.T) {
+	handler := GitHubAPIHandler{}
+	assert.Equal(t, "application/vnd.github+json", handler.GetAcceptHeader(), "The Accept header should request the GitHub media type.")
+}
@@ -0,0 +1,57 @@
+// apiintegrations/github/github_api_url.go
+package github
+
+import (
+	"fmt"
+
+	"github.com/deploymenttheory/go-api-http-client/helpers"
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"go.uber.org/zap"
+)
+
+// IsEnterpriseServer reports whether the handler targets a GitHub Enterprise Server instance rather than github.com.
+func (g *GitHubAPIHandler) IsEnterpriseServer() bool {
+	return g.InstanceName != "" && g.InstanceName != DefaultAuthDomain && g.InstanceName != DefaultBaseDomain
+}
+
+// SetBaseDomain returns the appropriate base domain for URL construction.
+// It uses g.OverrideBaseDomain if set, otherwise falls back to DefaultBaseDomain.
+func (g *GitHubAPIHandler) SetBaseDomain() string {
+	if g.OverrideBaseDomain != "" {
+		return g.OverrideBaseDomain
+	}
+	return DefaultBaseDomain
+}
+
+// ConstructAPIResourceEndpoint constructs the full URL for a GitHub API resource endpoint path and logs the URL.
+// BaseURL takes precedence when set; otherwise GitHub Enterprise Server instances serve the REST API from
+// https://<host>/api/v3 and github.com from the base domain.
+func (g *GitHubAPIHandler) ConstructAPIResourceEndpoint(endpointPath string, log logger.Logger) string {
+	var url string
+	if g.BaseURL != "" {
+		url = helpers.JoinURL(g.BaseURL, endpointPath)
+	} else if g.IsEnterpriseServer() {
+		url = fmt.Sprintf("https://%s%s%s", g.InstanceName, EnterpriseServerAPIPath, endpointPath)
+	} else {
+		url = fmt.Sprintf("https://%s%s", g.SetBaseDomain(), endpointPath)
+	}
+	g.Logger.Debug(fmt.Sprintf("Constructed %s API resource endpoint URL", APIName), zap.String("URL", url))
+	return url
+}
+
+// ConstructAPIAuthEndpoint constructs the full URL for a GitHub OAuth endpoint path and logs the URL.
+// OAuth endpoints are served from the web host rather than the API host, i.e. https://github.com or https://<host>,
+// unless AuthBaseURL is set.
+func (g *GitHubAPIHandler) ConstructAPIAuthEndpoint(endpointPath string, log logger.Logger) string {
+	authBaseURL := g.AuthBaseURL
+	if authBaseURL == "" {
+		authDomain := DefaultAuthDomain
+		if g.IsEnterpriseServer() {
+			authDomain = g.InstanceName
+		}
+		authBaseURL = "https://" + authDomain
+	}
+	url := helpers.JoinURL(authBaseURL, endpointPath)
+	g.Logger.Debug(fmt.Sprintf("Constructed %s API authentication URL", APIName), zap.String("URL", url))
+	return url
+}
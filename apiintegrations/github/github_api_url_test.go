@@ -0,0 +1,78 @@
+// apiintegrations/github/github_api_url_test.go
+package github
+
+import (
+	"testing"
+
+	"github.com/deploymenttheory/go-api-http-client/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestConstructAPIResourceEndpoint tests the ConstructAPIResourceEndpoint function.
+func TestConstructAPIResourceEndpoint(t *testing.T) {
+	tests := []struct {
+		name         string
+		instanceName string
+		expectedURL  string
+	}{
+		{"github.com", "github.com", "https://api.github.com/repos/octo/hello"},
+		{"Empty instance name", "", "https://api.github.com/repos/octo/hello"},
+		{"GitHub Enterprise Server", "github.example.com", "https://github.example.com/api/v3/repos/octo/hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLog := mocklogger.NewMockLogger()
+			mockLog.On("Debug", mock.AnythingOfType("string"), mock.Anything).Once()
+
+			handler := GitHubAPIHandler{InstanceName: tt.instanceName, Logger: mockLog}
+			resultURL := handler.ConstructAPIResourceEndpoint("/repos/octo/hello", mockLog)
+
+			assert.Equal(t, tt.expectedURL, resultURL, "URL should match expected format")
+			mockLog.AssertExpectations(t)
+		})
+	}
+}
+
+// TestConstructAPIAuthEndpoint tests the ConstructAPIAuthEndpoint function.
+func TestConstructAPIAuthEndpoint(t *testing.T) {
+	tests := []struct {
+		name         string
+		instanceName string
+		expectedURL  string
+	}{
+		{"github.com", "github.com", "https://github.com/login/oauth/access_token"},
+		{"GitHub Enterprise Server", "github.example.com", "https://github.example.com/login/oauth/access_token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLog := mocklogger.NewMockLogger()
+			mockLog.On("Debug", mock.AnythingOfType("string"), mock.Anything).Once()
+
+			handler := GitHubAPIHandler{InstanceName: tt.instanceName, Logger: mockLog}
+			resultURL := handler.ConstructAPIAuthEndpoint(OAuthTokenEndpoint, mockLog)
+
+			assert.Equal(t, tt.expectedURL, resultURL, "URL should match expected format")
+			mockLog.AssertExpectations(t)
+		})
+	}
+}
+
+// TestConstructEndpointsWithBaseURLOverrides tests that BaseURL and AuthBaseURL replace the default hosts.
+func TestConstructEndpointsWithBaseURLOverrides(t *testing.T) {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Debug", mock.AnythingOfType("string"), mock.Anything).Twice()
+
+	handler := GitHubAPIHandler{
+		InstanceName: "github.example.com",
+		BaseURL:      "https://proxy.example.com/github/api/v3",
+		AuthBaseURL:  "https://proxy.example.com/github",
+		Logger:       mockLog,
+	}
+
+	assert.Equal(t, "https://proxy.example.com/github/api/v3/repos/octo/hello", handler.ConstructAPIResourceEndpoint("/repos/octo/hello", mockLog))
+	assert.Equal(t, "https://proxy.example.com/github/login/oauth/access_token", handler.ConstructAPIAuthEndpoint(OAuthTokenEndpoint, mockLog))
+	mockLog.AssertExpectations(t)
+}
@@ -0,0 +1,28 @@
+// jamfpro_api_concurrency.go
+package jamfpro
+
+import (
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/concurrency"
+)
+
+// Concurrency defaults for Jamf Pro. A Jamf Pro server, often self hosted, serves few requests at once and slows
+// down well before it errors, so the limit stays low, slow responses are tolerated for longer and requests queue
+// longer for a permit.
+const (
+	MaxConcurrency                = 5                      // MaxConcurrency: the highest concurrency limit.
+	PermitTimeout                 = 30 * time.Second       // PermitTimeout: the longest wait for a concurrency permit.
+	ResponseTimeCriticalThreshold = 5 * time.Second        // ResponseTimeCriticalThreshold: the response time treated as overload.
+	AcceptableAverageResponseTime = 500 * time.Millisecond // AcceptableAverageResponseTime: the average response time above which unstable response times scale down.
+)
+
+// ConcurrencyDefaults returns the concurrency tuning suited to Jamf Pro, used for settings the client configuration leaves unset.
+func (j *JamfAPIHandler) ConcurrencyDefaults() concurrency.Tuning {
+	return concurrency.Tuning{
+		PermitTimeout:                 PermitTimeout,
+		MaxConcurrency:                MaxConcurrency,
+		ResponseTimeCriticalThreshold: ResponseTimeCriticalThreshold,
+		AcceptableAverageResponseTime: AcceptableAverageResponseTime,
+	}
+}
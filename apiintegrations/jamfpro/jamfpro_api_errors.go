@@ -0,0 +1,49 @@
+// jamfpro_api_errors.go
+package jamfpro
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/deploymenttheory/go-api-http-client/response"
+)
+
+// JamfProErrorCause represents an entry of the errors array of a Jamf Pro API error.
+type JamfProErrorCause struct {
+	Code        string `json:"code"`
+	Field       string `json:"field,omitempty"`
+	Description string `json:"description"`
+	ID          string `json:"id,omitempty"`
+}
+
+// JamfProErrorResponse represents the body of a Jamf Pro API error response,
+// e.g. {"httpStatus":400,"errors":[{"code":"INVALID_FIELD","field":"name","description":"..."}]}.
+type JamfProErrorResponse struct {
+	HTTPStatus int                 `json:"httpStatus"`
+	Errors     []JamfProErrorCause `json:"errors"`
+}
+
+// ParseErrorResponse fills the APIError from a Jamf Pro API error body. The first cause provides the code, target
+// field and message; every cause is listed in the details. Classic API errors, which are HTML, are left to the
+// generic parsing.
+func (j *JamfAPIHandler) ParseErrorResponse(resp *http.Response, body []byte, apiError *response.APIError) error {
+	var jamfError JamfProErrorResponse
+	if err := json.Unmarshal(body, &jamfError); err != nil || len(jamfError.Errors) == 0 {
+		return nil
+	}
+
+	first := jamfError.Errors[0]
+	apiError.Code = first.Code
+	apiError.Target = first.Field
+	if first.Description != "" {
+		apiError.Message = first.Description
+	}
+	for _, cause := range jamfError.Errors {
+		detail := cause.Code + ": " + cause.Description
+		if cause.Field != "" {
+			detail = cause.Field + ": " + detail
+		}
+		apiError.Details = append(apiError.Details, detail)
+	}
+	return nil
+}
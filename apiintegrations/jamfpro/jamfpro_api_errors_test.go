@@ -0,0 +1,35 @@
+// jamfpro_api_errors_test.go
+package jamfpro
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/deploymenttheory/go-api-http-client/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseErrorResponse tests that Jamf Pro API error bodies fill the code, target, message and details.
+func TestParseErrorResponse(t *testing.T) {
+	handler := &JamfAPIHandler{}
+	body := `{"httpStatus":400,"errors":[` +
+		`{"code":"INVALID_FIELD","field":"name","description":"Name must not be blank","id":"0"},` +
+		`{"code":"DUPLICATE_FIELD","field":"serialNumber","description":"Serial number already in use","id":"0"}]}`
+
+	apiError := &response.APIError{StatusCode: http.StatusBadRequest, Message: "API Error Response"}
+	require.NoError(t, handler.ParseErrorResponse(&http.Response{}, []byte(body), apiError))
+
+	assert.Equal(t, "INVALID_FIELD", apiError.Code)
+	assert.Equal(t, "name", apiError.Target)
+	assert.Equal(t, "Name must not be blank", apiError.Message)
+	assert.Equal(t, []string{
+		"name: INVALID_FIELD: Name must not be blank",
+		"serialNumber: DUPLICATE_FIELD: Serial number already in use",
+	}, apiError.Details)
+
+	// Classic API HTML errors are left untouched
+	untouched := &response.APIError{Message: "Unauthorized"}
+	require.NoError(t, handler.ParseErrorResponse(&http.Response{}, []byte(`<html><p>Unauthorized</p></html>`), untouched))
+	assert.Equal(t, &response.APIError{Message: "Unauthorized"}, untouched)
+}
@@ -5,19 +5,16 @@ import (
 
 	"encoding/json"
 	"log"
+
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/endpointconfig"
 )
 
-// EndpointConfig is a struct that holds configuration details for a specific API endpoint.
-// It includes what type of content it can accept and what content type it should send.
-type EndpointConfig struct {
-	Accept      string  `json:"accept"`       // Accept specifies the MIME type the endpoint can handle in responses.
-	ContentType *string `json:"content_type"` // ContentType, if not nil, specifies the MIME type to set for requests sent to the endpoint. A pointer is used to distinguish between a missing field and an empty string.
-}
+// EndpointConfig holds the Accept and Content-Type exceptions for an API endpoint.
+type EndpointConfig = endpointconfig.EndpointConfig
 
-// ConfigMap is a map that associates endpoint URL patterns with their corresponding configurations.
-// The map's keys are strings that identify the endpoint, and the values are EndpointConfig structs
-// that hold the configuration for that endpoint.
-type ConfigMap map[string]EndpointConfig
+// ConfigMap associates endpoint path patterns with their configurations. See endpointconfig.ConfigMap.Match
+// for how patterns are matched.
+type ConfigMap = endpointconfig.ConfigMap
 
 // Variables
 var configMap ConfigMap
@@ -43,3 +40,8 @@ func loadAPIExceptionsConfiguration() error {
 	// Unmarshal the embedded default configuration into the global configMap.
 	return json.Unmarshal(jamfpro_api_exceptions_configuration, &configMap)
 }
+
+// DefaultEndpoints returns a copy of the embedded endpoint configuration, for merging with user overrides.
+func DefaultEndpoints() ConfigMap {
+	return configMap.Merge(nil)
+}
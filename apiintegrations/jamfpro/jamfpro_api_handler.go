@@ -8,5 +8,8 @@ import "github.com/deploymenttheory/go-api-http-client/logger"
 type JamfAPIHandler struct {
 	OverrideBaseDomain string        // OverrideBaseDomain is used to override the base domain for URL construction.
 	InstanceName       string        // InstanceName is the name of the Jamf instance.
+	BaseURL            string        // BaseURL, if set, replaces the scheme, host, port and path prefix used for resource endpoints.
+	AuthBaseURL        string        // AuthBaseURL, if set, replaces the scheme, host, port and path prefix used for authentication endpoints.
+	Endpoints          ConfigMap     // Endpoints, if set, replaces the embedded endpoint configuration; see DefaultEndpoints.
 	Logger             logger.Logger // Logger is the structured logger used for logging.
 }
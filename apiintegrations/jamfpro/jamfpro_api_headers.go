@@ -9,7 +9,7 @@ import (
 )
 
 // GetContentTypeHeader determines the appropriate Content-Type header for a given API endpoint.
-// It attempts to find a content type that matches the endpoint prefix in the global configMap.
+// It attempts to find a content type that matches the endpoint in the endpoint configuration.
 // If a match is found and the content type is defined (not nil), it returns the specified content type.
 // If the content type is nil or no match is found in configMap, it falls back to default behaviors:
 // - For url endpoints starting with "/JSSResource", it defaults to "application/xml" for the Classic API.
@@ -18,16 +18,14 @@ import (
 // This method logs the decision process at various stages for debugging purposes.
 func (j *JamfAPIHandler) GetContentTypeHeader(endpoint string, log logger.Logger) string {
 	// Dynamic lookup from configuration should be the first priority
-	for key, config := range configMap {
-		if strings.HasPrefix(endpoint, key) {
-			if config.ContentType != nil {
-				j.Logger.Debug("Content-Type for endpoint found in configMap", zap.String("endpoint", endpoint), zap.String("content_type", *config.ContentType))
-				return *config.ContentType
-			}
-			j.Logger.Debug("Content-Type for endpoint is nil in configMap, handling as special case", zap.String("endpoint", endpoint))
-			// If a nil ContentType is an expected case, do not set Content-Type header.
-			return "" // Return empty to indicate no Content-Type should be set.
+	if config, ok := j.endpointConfig(endpoint); ok {
+		if config.ContentType != nil {
+			j.Logger.Debug("Content-Type for endpoint found in configMap", zap.String("endpoint", endpoint), zap.String("content_type", *config.ContentType))
+			return *config.ContentType
 		}
+		j.Logger.Debug("Content-Type for endpoint is nil in configMap, handling as special case", zap.String("endpoint", endpoint))
+		// If a nil ContentType is an expected case, do not set Content-Type header.
+		return "" // Return empty to indicate no Content-Type should be set.
 	}
 
 	// If no specific configuration is found, then check for standard URL patterns.
@@ -70,13 +68,34 @@ func (j *JamfAPIHandler) GetAcceptHeader() string {
 	return weightedAcceptHeader
 }
 
+// GetAcceptHeaderForEndpoint returns the Accept header for a given API endpoint. Endpoints that
+// return a specific representation, such as images or CSV, are configured in the endpoint configuration;
+// all other endpoints use the weighted Accept header.
+func (j *JamfAPIHandler) GetAcceptHeaderForEndpoint(endpoint string) string {
+	if config, ok := j.endpointConfig(endpoint); ok && config.Accept != "" {
+		return config.Accept
+	}
+	return j.GetAcceptHeader()
+}
+
 // GetAPIRequestHeaders returns a map of standard headers required for making API requests.
 func (j *JamfAPIHandler) GetAPIRequestHeaders(endpoint string) map[string]string {
 	headers := map[string]string{
-		"Accept":        j.GetAcceptHeader(),                        // Dynamically set based on API requirements.
+		"Accept":        j.GetAcceptHeaderForEndpoint(endpoint),     // Dynamically set based on the endpoint.
 		"Content-Type":  j.GetContentTypeHeader(endpoint, j.Logger), // Dynamically set based on the endpoint.
 		"Authorization": "",                                         // To be set by the client with the actual token.
 		"User-Agent":    "go-api-http-client-jamfpro-handler",       // To be set by the client, usually with application info.
 	}
 	return headers
 }
+
+// endpointConfig returns the endpoint configuration rule matching the endpoint. The handler's Endpoints
+// are used when set, otherwise the embedded configuration.
+func (j *JamfAPIHandler) endpointConfig(endpoint string) (EndpointConfig, bool) {
+	endpoints := j.Endpoints
+	if endpoints == nil {
+		endpoints = configMap
+	}
+	_, config, ok := endpoints.Match(endpoint)
+	return config, ok
+}
@@ -0,0 +1,54 @@
+// jamfpro_api_headers_test.go
+package jamfpro
+
+import (
+	"testing"
+
+	"github.com/deploymenttheory/go-api-http-client/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestGetAPIRequestHeaders tests that the Accept and Content-Type headers follow the endpoint configuration.
+func TestGetAPIRequestHeaders(t *testing.T) {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Debug", mock.AnythingOfType("string"), mock.Anything).Maybe()
+	handler := JamfAPIHandler{InstanceName: "acme", Logger: mockLog}
+
+	tests := []struct {
+		name        string
+		endpoint    string
+		accept      string
+		contentType string
+	}{
+		{"Classic API", "/JSSResource/computers", handler.GetAcceptHeader(), "application/xml"},
+		{"Jamf Pro API", "/api/v1/computers-inventory", handler.GetAcceptHeader(), "application/json"},
+		{"CSV template", "/api/v2/inventory-preload/csv-template", "text/csv", ""},
+		{"Icon download", "/api/v1/icon/download/7", "image/*", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := handler.GetAPIRequestHeaders(tt.endpoint)
+			assert.Equal(t, tt.accept, headers["Accept"])
+			assert.Equal(t, tt.contentType, headers["Content-Type"])
+		})
+	}
+}
+
+// TestGetAPIRequestHeadersEndpoints tests that the handler's Endpoints replace the embedded configuration.
+func TestGetAPIRequestHeadersEndpoints(t *testing.T) {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Debug", mock.AnythingOfType("string"), mock.Anything).Maybe()
+	contentType := "text/plain"
+	handler := JamfAPIHandler{
+		InstanceName: "acme",
+		Endpoints:    DefaultEndpoints().Merge(ConfigMap{"/api/v1/icon/download/": {Accept: "image/png", ContentType: &contentType}}),
+		Logger:       mockLog,
+	}
+
+	headers := handler.GetAPIRequestHeaders("/api/v1/icon/download/7")
+	assert.Equal(t, "image/png", headers["Accept"])
+	assert.Equal(t, "text/plain", headers["Content-Type"])
+	assert.Equal(t, "image/*", configMap["/api/v1/icon/download/"].Accept)
+}
@@ -0,0 +1,201 @@
+// jamfpro_api_rsql.go
+package jamfpro
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/helpers"
+)
+
+// RSQL sort directions.
+const (
+	SortAsc  = "asc"  // SortAsc: ascending sort order.
+	SortDesc = "desc" // SortDesc: descending sort order.
+)
+
+// RSQLQuery builds the query parameters accepted by Jamf Pro API collection endpoints: an RSQL filter,
+// sort criteria, inventory sections and paging. Endpoint produces the endpoint string passed to DoRequest,
+// which the handler appends to the instance URL like any other resource endpoint.
+type RSQLQuery struct {
+	filter   RSQLExpression
+	sort     []string
+	sections []string
+	page     *int
+	pageSize *int
+}
+
+// NewRSQLQuery returns an empty RSQL query.
+func NewRSQLQuery() *RSQLQuery {
+	return &RSQLQuery{}
+}
+
+// Filter sets the filter expression. Use the expression functions such as Eq, Ne and And to build it.
+func (q *RSQLQuery) Filter(expression RSQLExpression) *RSQLQuery {
+	q.filter = expression
+	return q
+}
+
+// Sort adds a sort criterion, e.g. Sort("id", SortDesc) for "sort=id:desc".
+func (q *RSQLQuery) Sort(property, direction string) *RSQLQuery {
+	q.sort = append(q.sort, property+":"+direction)
+	return q
+}
+
+// Section adds inventory sections to return, e.g. "GENERAL" or "HARDWARE".
+func (q *RSQLQuery) Section(sections ...string) *RSQLQuery {
+	q.sections = append(q.sections, sections...)
+	return q
+}
+
+// Page sets the zero based page number.
+func (q *RSQLQuery) Page(page int) *RSQLQuery {
+	q.page = &page
+	return q
+}
+
+// PageSize sets the number of results per page.
+func (q *RSQLQuery) PageSize(pageSize int) *RSQLQuery {
+	q.pageSize = &pageSize
+	return q
+}
+
+// Encode returns the query string, without a leading "?". Parameters are written in a fixed order.
+func (q *RSQLQuery) Encode() string {
+	var params []string
+	add := func(name, value string) {
+		params = append(params, name+"="+helpers.EscapeQueryValue(value))
+	}
+
+	for _, section := range q.sections {
+		add("section", section)
+	}
+	if q.page != nil {
+		add("page", strconv.Itoa(*q.page))
+	}
+	if q.pageSize != nil {
+		add("page-size", strconv.Itoa(*q.pageSize))
+	}
+	if len(q.sort) > 0 {
+		add("sort", strings.Join(q.sort, ","))
+	}
+	if q.filter != "" {
+		add("filter", string(q.filter))
+	}
+
+	return strings.Join(params, "&")
+}
+
+// Endpoint appends the query string to an endpoint path, e.g. "/api/v1/computers-inventory" becomes
+// "/api/v1/computers-inventory?section=GENERAL&filter=general.name%3D%3D%22x%22".
+func (q *RSQLQuery) Endpoint(endpointPath string) string {
+	return helpers.AppendQuery(endpointPath, q.Encode())
+}
+
+// RSQLExpression is an RSQL filter expression.
+type RSQLExpression string
+
+// Eq returns a "property==value" comparison. Jamf Pro treats * in the value as a wildcard.
+func Eq(property string, value interface{}) RSQLExpression {
+	return rsqlCompare(property, "==", value)
+}
+
+// Ne returns a "property!=value" comparison.
+func Ne(property string, value interface{}) RSQLExpression {
+	return rsqlCompare(property, "!=", value)
+}
+
+// Lt returns a "property<value" comparison.
+func Lt(property string, value interface{}) RSQLExpression {
+	return rsqlCompare(property, "<", value)
+}
+
+// Le returns a "property<=value" comparison.
+func Le(property string, value interface{}) RSQLExpression {
+	return rsqlCompare(property, "<=", value)
+}
+
+// Gt returns a "property>value" comparison.
+func Gt(property string, value interface{}) RSQLExpression {
+	return rsqlCompare(property, ">", value)
+}
+
+// Ge returns a "property>=value" comparison.
+func Ge(property string, value interface{}) RSQLExpression {
+	return rsqlCompare(property, ">=", value)
+}
+
+// In returns a "property=in=(value,...)" comparison.
+func In(property string, values ...interface{}) RSQLExpression {
+	return rsqlList(property, "=in=", values)
+}
+
+// Out returns a "property=out=(value,...)" comparison.
+func Out(property string, values ...interface{}) RSQLExpression {
+	return rsqlList(property, "=out=", values)
+}
+
+// And combines expressions with the RSQL and operator ";".
+func And(expressions ...RSQLExpression) RSQLExpression {
+	return RSQLExpression(strings.Join(nonEmptyRSQL(expressions), ";"))
+}
+
+// Or combines expressions with the RSQL or operator ",". The group is parenthesised so it keeps its
+// precedence when combined with And. Without any non-empty expressions it returns an empty expression.
+func Or(expressions ...RSQLExpression) RSQLExpression {
+	parts := nonEmptyRSQL(expressions)
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return RSQLExpression(parts[0])
+	}
+	return RSQLExpression("(" + strings.Join(parts, ",") + ")")
+}
+
+// QuoteRSQLValue formats a value as an RSQL argument. Strings are always double quoted with embedded quotes
+// and backslashes escaped, so values containing commas, semicolons, quotes or spaces cannot break the expression.
+// Times are written in RFC 3339 format in UTC, which is how Jamf Pro reports and compares dates.
+func QuoteRSQLValue(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return `"` + v.UTC().Format(time.RFC3339) + `"`
+	default:
+		return `"` + helpers.EscapeQuotes(fmt.Sprint(v)) + `"`
+	}
+}
+
+// rsqlCompare returns a comparison with the value quoted as an RSQL argument.
+func rsqlCompare(property, operator string, value interface{}) RSQLExpression {
+	return RSQLExpression(property + operator + QuoteRSQLValue(value))
+}
+
+// rsqlList returns a comparison against a list of values.
+func rsqlList(property, operator string, values []interface{}) RSQLExpression {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = QuoteRSQLValue(value)
+	}
+	return RSQLExpression(property + operator + "(" + strings.Join(quoted, ",") + ")")
+}
+
+// nonEmptyRSQL returns the non-empty expressions as strings.
+func nonEmptyRSQL(expressions []RSQLExpression) []string {
+	parts := make([]string, 0, len(expressions))
+	for _, expression := range expressions {
+		if expression != "" {
+			parts = append(parts, string(expression))
+		}
+	}
+	return parts
+}
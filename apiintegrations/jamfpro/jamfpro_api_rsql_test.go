@@ -0,0 +1,76 @@
+// jamfpro_api_rsql_test.go
+package jamfpro
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRSQLExpressions tests the RSQL expression functions.
+func TestRSQLExpressions(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression RSQLExpression
+		expected   string
+	}{
+		{"Eq and Ne", And(Eq("general.name", "x"), Ne("general.platform", "Mac")), `general.name=="x";general.platform!="Mac"`},
+		{"Comma and quote in value", Eq("general.name", `Smith, "Bob"'s Mac`), `general.name=="Smith, \"Bob\"'s Mac"`},
+		{"Backslash in value", Eq("userAndLocation.username", `CORP\bob`), `userAndLocation.username=="CORP\\bob"`},
+		{"Wildcard", Eq("general.name", "Lab-*"), `general.name=="Lab-*"`},
+		{"Numbers", And(Ge("id", 10), Lt("id", 20)), `id>=10;id<20`},
+		{"In and Out", And(In("id", 1, 2), Out("general.platform", "iOS", "tvOS")), `id=in=(1,2);general.platform=out=("iOS","tvOS")`},
+		{"And with Or", And(Eq("a", "1"), Or(Eq("b", "2"), Eq("c", "3"))), `a=="1";(b=="2",c=="3")`},
+		{"Or of one expression", Or(Eq("a", "1")), `a=="1"`},
+		{"Or of no expressions", Or(), ""},
+		{"Time", Ge("general.reportDate", time.Date(2024, 5, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))), `general.reportDate>="2024-05-01T12:00:00Z"`},
+		{"Or of empty expressions", And(Eq("a", "1"), Or("", "")), `a=="1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, string(tt.expression))
+		})
+	}
+}
+
+// TestRSQLQueryEndpoint tests that the query is encoded and round trips through URL parsing.
+func TestRSQLQueryEndpoint(t *testing.T) {
+	endpoint := NewRSQLQuery().
+		Filter(And(Eq("general.name", "a,b"), Ne("general.platform", "Mac"))).
+		Sort("general.name", SortAsc).
+		Sort("id", SortDesc).
+		Section("GENERAL", "HARDWARE").
+		Page(0).
+		PageSize(100).
+		Endpoint("/api/v1/computers-inventory")
+
+	parsed, err := url.Parse(endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/computers-inventory", parsed.Path)
+
+	query := parsed.Query()
+	assert.Equal(t, []string{"GENERAL", "HARDWARE"}, query["section"])
+	assert.Equal(t, "general.name:asc,id:desc", query.Get("sort"))
+	assert.Equal(t, "0", query.Get("page"))
+	assert.Equal(t, "100", query.Get("page-size"))
+	assert.Equal(t, `general.name=="a,b";general.platform!="Mac"`, query.Get("filter"))
+}
+
+// TestRSQLQueryResourceEndpoint tests that the handler builds the full URL for an RSQL query endpoint.
+func TestRSQLQueryResourceEndpoint(t *testing.T) {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Debug", mock.AnythingOfType("string"), mock.Anything).Once()
+
+	handler := JamfAPIHandler{InstanceName: "acme", Logger: mockLog}
+	endpoint := NewRSQLQuery().Sort("id", SortDesc).Endpoint("/api/v1/computers-inventory")
+
+	assert.Equal(t, "https://acme.jamfcloud.com/api/v1/computers-inventory?sort=id%3Adesc", handler.ConstructAPIResourceEndpoint(endpoint, mockLog))
+	assert.Equal(t, "/api/v1/computers-inventory", NewRSQLQuery().Endpoint("/api/v1/computers-inventory"))
+	mockLog.AssertExpectations(t)
+}
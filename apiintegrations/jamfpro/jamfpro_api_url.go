@@ -4,6 +4,7 @@ package jamfpro
 import (
 	"fmt"
 
+	"github.com/deploymenttheory/go-api-http-client/helpers"
 	"github.com/deploymenttheory/go-api-http-client/logger"
 	"go.uber.org/zap"
 )
@@ -17,11 +18,28 @@ func (j *JamfAPIHandler) SetBaseDomain() string {
 	return DefaultBaseDomain
 }
 
+// SetBaseURL returns the base URL for resource endpoints. It uses j.BaseURL if set, which allows on-premises
+// servers such as https://jamf.corp:8443, otherwise the URL is built from the instance name and base domain.
+func (j *JamfAPIHandler) SetBaseURL() string {
+	if j.BaseURL != "" {
+		return j.BaseURL
+	}
+	return fmt.Sprintf("https://%s%s", j.InstanceName, j.SetBaseDomain())
+}
+
+// SetAuthBaseURL returns the base URL for authentication endpoints. It uses j.AuthBaseURL if set, otherwise
+// the resource base URL, as Jamf Pro serves authentication from the same host.
+func (j *JamfAPIHandler) SetAuthBaseURL() string {
+	if j.AuthBaseURL != "" {
+		return j.AuthBaseURL
+	}
+	return j.SetBaseURL()
+}
+
 // ConstructAPIResourceEndpoint constructs the full URL for a Jamf API resource endpoint path and logs the URL.
 // It uses the instance name to construct the full URL.
 func (j *JamfAPIHandler) ConstructAPIResourceEndpoint(endpointPath string, log logger.Logger) string {
-	urlBaseDomain := j.SetBaseDomain()
-	url := fmt.Sprintf("https://%s%s%s", j.InstanceName, urlBaseDomain, endpointPath)
+	url := helpers.JoinURL(j.SetBaseURL(), endpointPath)
 	j.Logger.Debug(fmt.Sprintf("Constructed %s API resource endpoint URL", APIName), zap.String("URL", url))
 	return url
 }
@@ -29,8 +47,7 @@ func (j *JamfAPIHandler) ConstructAPIResourceEndpoint(endpointPath string, log l
 // ConstructAPIAuthEndpoint constructs the full URL for a Jamf API auth endpoint path and logs the URL.
 // It uses the instance name to construct the full URL.
 func (j *JamfAPIHandler) ConstructAPIAuthEndpoint(endpointPath string, log logger.Logger) string {
-	urlBaseDomain := j.SetBaseDomain()
-	url := fmt.Sprintf("https://%s%s%s", j.InstanceName, urlBaseDomain, endpointPath)
+	url := helpers.JoinURL(j.SetAuthBaseURL(), endpointPath)
 	j.Logger.Debug(fmt.Sprintf("Constructed %s API authentication URL", APIName), zap.String("URL", url))
 	return url
 }
@@ -0,0 +1,26 @@
+// apiintegrations/msgraph/msgraph_api_concurrency.go
+package msgraph
+
+import (
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/concurrency"
+)
+
+// Concurrency defaults for Microsoft Graph. Graph is built for many concurrent requests and protects itself by
+// throttling with 429 responses rather than slowing down, so the limit may grow higher and responses are expected
+// to stay fast.
+const (
+	MaxConcurrency                = 20                     // MaxConcurrency: the highest concurrency limit.
+	ResponseTimeCriticalThreshold = 2 * time.Second        // ResponseTimeCriticalThreshold: the response time treated as overload.
+	AcceptableAverageResponseTime = 200 * time.Millisecond // AcceptableAverageResponseTime: the average response time above which unstable response times scale down.
+)
+
+// ConcurrencyDefaults returns the concurrency tuning suited to Microsoft Graph, used for settings the client configuration leaves unset.
+func (g *GraphAPIHandler) ConcurrencyDefaults() concurrency.Tuning {
+	return concurrency.Tuning{
+		MaxConcurrency:                MaxConcurrency,
+		ResponseTimeCriticalThreshold: ResponseTimeCriticalThreshold,
+		AcceptableAverageResponseTime: AcceptableAverageResponseTime,
+	}
+}
@@ -0,0 +1,63 @@
+// apiintegrations/msgraph/msgraph_api_errors.go
+package msgraph
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/deploymenttheory/go-api-http-client/response"
+)
+
+// GraphErrorDetail represents an entry of the details array of a Microsoft Graph error.
+type GraphErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Target  string `json:"target,omitempty"`
+}
+
+// GraphInnerError holds the diagnostic information Microsoft Graph returns with an error.
+type GraphInnerError struct {
+	Code            string `json:"code,omitempty"`
+	RequestID       string `json:"request-id,omitempty"`
+	ClientRequestID string `json:"client-request-id,omitempty"`
+	Date            string `json:"date,omitempty"`
+}
+
+// GraphErrorResponse represents the body of a Microsoft Graph error response,
+// e.g. {"error":{"code":"Request_ResourceNotFound","message":"...","innerError":{"request-id":"..."}}}.
+type GraphErrorResponse struct {
+	Error struct {
+		Code       string             `json:"code"`
+		Message    string             `json:"message"`
+		Target     string             `json:"target,omitempty"`
+		Details    []GraphErrorDetail `json:"details,omitempty"`
+		InnerError *GraphInnerError   `json:"innerError,omitempty"`
+	} `json:"error"`
+}
+
+// ParseErrorResponse fills the APIError from a Microsoft Graph error body. The request and client request IDs
+// from the inner error are what Microsoft support asks for. Bodies in other formats are left to the generic parsing.
+func (g *GraphAPIHandler) ParseErrorResponse(resp *http.Response, body []byte, apiError *response.APIError) error {
+	var graphError GraphErrorResponse
+	if err := json.Unmarshal(body, &graphError); err != nil || graphError.Error.Code == "" {
+		return nil
+	}
+
+	apiError.Code = graphError.Error.Code
+	apiError.Target = graphError.Error.Target
+	if graphError.Error.Message != "" {
+		apiError.Message = graphError.Error.Message
+	}
+	for _, detail := range graphError.Error.Details {
+		apiError.Details = append(apiError.Details, detail.Code+": "+detail.Message)
+	}
+	if inner := graphError.Error.InnerError; inner != nil {
+		if inner.RequestID != "" {
+			apiError.RequestID = inner.RequestID
+		}
+		if inner.ClientRequestID != "" {
+			apiError.CorrelationID = inner.ClientRequestID
+		}
+	}
+	return nil
+}
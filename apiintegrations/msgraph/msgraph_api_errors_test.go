@@ -0,0 +1,34 @@
+// apiintegrations/msgraph/msgraph_api_errors_test.go
+package msgraph
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/deploymenttheory/go-api-http-client/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseErrorResponse tests that Graph error bodies fill the code, message, details and request IDs.
+func TestParseErrorResponse(t *testing.T) {
+	handler := &GraphAPIHandler{}
+	body := `{"error":{"code":"Request_BadRequest","message":"Invalid object identifier 'x'.","target":"id",` +
+		`"details":[{"code":"InvalidValue","message":"'x' is not a GUID."}],` +
+		`"innerError":{"date":"2024-05-01T10:00:00","request-id":"6f1c0b","client-request-id":"a7d2e4"}}}`
+
+	apiError := &response.APIError{StatusCode: http.StatusBadRequest, Message: "API Error Response", RequestID: "from-header"}
+	require.NoError(t, handler.ParseErrorResponse(&http.Response{}, []byte(body), apiError))
+
+	assert.Equal(t, "Request_BadRequest", apiError.Code)
+	assert.Equal(t, "Invalid object identifier 'x'.", apiError.Message)
+	assert.Equal(t, "id", apiError.Target)
+	assert.Equal(t, []string{"InvalidValue: 'x' is not a GUID."}, apiError.Details)
+	assert.Equal(t, "6f1c0b", apiError.RequestID)
+	assert.Equal(t, "a7d2e4", apiError.CorrelationID)
+
+	// Other bodies are left untouched
+	untouched := &response.APIError{Message: "API Error Response"}
+	require.NoError(t, handler.ParseErrorResponse(&http.Response{}, []byte(`<html></html>`), untouched))
+	assert.Equal(t, &response.APIError{Message: "API Error Response"}, untouched)
+}
@@ -8,5 +8,11 @@ type GraphAPIHandler struct {
 	OverrideBaseDomain string        // OverrideBaseDomain is used to override the base domain for URL construction.
 	TenantID           string        // TenantID used for constructing the authentication endpoint.
 	TenantName         string        // TenantName used for constructing the authentication endpoint.
+	Cloud              string        // Cloud selects the national cloud: "global" (default), "usgovhigh", "usgovdod" or "china".
+	APIVersion         string        // APIVersion is the Graph version, "v1.0" (default) or "beta", prefixed to endpoints that do not name one.
+	Prefer             []string      // Prefer lists preferences sent in the Prefer header, e.g. "odata.maxpagesize=100" or "return=minimal".
+	BaseURL            string        // BaseURL, if set, replaces the scheme, host, port and path prefix used for resource endpoints.
+	AuthBaseURL        string        // AuthBaseURL, if set, replaces the scheme, host, port and path prefix used for authentication endpoints.
+	Endpoints          ConfigMap     // Endpoints, if set, replaces the embedded endpoint configuration; see DefaultEndpoints.
 	Logger             logger.Logger // Logger is the structured logger used for logging.
 }
@@ -1,6 +1,11 @@
 // apiintegrations/msgraph/msgraph_api_handler_constants.go
 package msgraph
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Endpoint constants represent the URL suffixes used for graph API token interactions.
 const (
 	APIName                            = "microsoft graph"                      // APIName: represents the name of the API.
@@ -15,9 +20,72 @@ const (
 	OAuthWithCertAuthenticationSupport = true                                   // OAuthWithCertAuthSuppport: A boolean to indicate if the API supports OAuth with client certificate authentication.
 )
 
+// API version constants select the Microsoft Graph version used for endpoints that do not name one.
+const (
+	APIVersionV1      = "v1.0"       // APIVersionV1: the generally available Microsoft Graph API.
+	APIVersionBeta    = "beta"       // APIVersionBeta: the Microsoft Graph beta API.
+	DefaultAPIVersion = APIVersionV1 // DefaultAPIVersion: the version used when none is configured.
+)
+
+// ValidateAPIVersion checks that the version is a Microsoft Graph API version. An empty version selects DefaultAPIVersion.
+func ValidateAPIVersion(version string) error {
+	switch version {
+	case "", APIVersionV1, APIVersionBeta:
+		return nil
+	default:
+		return fmt.Errorf("unsupported microsoft graph api version: %q, expected %s or %s", version, APIVersionV1, APIVersionBeta)
+	}
+}
+
+// National cloud constants select the Microsoft Graph deployment the handler talks to.
+const (
+	CloudGlobal    = "global"    // CloudGlobal: the global Microsoft Graph service.
+	CloudUSGovHigh = "usgovhigh" // CloudUSGovHigh: Microsoft Graph for US Government L4 (GCC High).
+	CloudUSGovDoD  = "usgovdod"  // CloudUSGovDoD: Microsoft Graph for US Government L5 (DoD).
+	CloudChina     = "china"     // CloudChina: Microsoft Graph China operated by 21Vianet.
+)
+
+// CloudEndpoints holds the hosts and token scope of a Microsoft Graph national cloud deployment.
+// They have to change together: a token issued by one login authority is rejected by the other clouds.
+type CloudEndpoints struct {
+	ResourceDomain string // ResourceDomain is the Microsoft Graph host for the cloud.
+	LoginBaseURL   string // LoginBaseURL is the Microsoft Entra login authority for the cloud.
+	TokenScope     string // TokenScope is the .default scope requested for the cloud's Graph resource.
+}
+
+// cloudEndpoints maps each national cloud to its Microsoft Graph and login endpoints.
+var cloudEndpoints = map[string]CloudEndpoints{
+	CloudGlobal:    {ResourceDomain: DefaultBaseDomain, LoginBaseURL: "https://login.microsoftonline.com", TokenScope: OAuthTokenScope},
+	CloudUSGovHigh: {ResourceDomain: "graph.microsoft.us", LoginBaseURL: "https://login.microsoftonline.us", TokenScope: "https://graph.microsoft.us/.default"},
+	CloudUSGovDoD:  {ResourceDomain: "dod-graph.microsoft.us", LoginBaseURL: "https://login.microsoftonline.us", TokenScope: "https://dod-graph.microsoft.us/.default"},
+	CloudChina:     {ResourceDomain: "microsoftgraph.chinacloudapi.cn", LoginBaseURL: "https://login.chinacloudapi.cn", TokenScope: "https://microsoftgraph.chinacloudapi.cn/.default"},
+}
+
+// LookupCloud returns the endpoints of a national cloud by name. An empty name selects the global cloud.
+func LookupCloud(cloud string) (CloudEndpoints, error) {
+	if cloud == "" {
+		cloud = CloudGlobal
+	}
+	endpoints, ok := cloudEndpoints[strings.ToLower(cloud)]
+	if !ok {
+		return CloudEndpoints{}, fmt.Errorf("unsupported microsoft graph cloud: %q, expected one of %s, %s, %s or %s", cloud, CloudGlobal, CloudUSGovHigh, CloudUSGovDoD, CloudChina)
+	}
+	return endpoints, nil
+}
+
+// GetCloudEndpoints returns the endpoints of the handler's national cloud, falling back to the global cloud
+// if the configured cloud is not recognised.
+func (g *GraphAPIHandler) GetCloudEndpoints() CloudEndpoints {
+	endpoints, err := LookupCloud(g.Cloud)
+	if err != nil {
+		return cloudEndpoints[CloudGlobal]
+	}
+	return endpoints
+}
+
 // GetDefaultBaseDomain returns the default base domain used for constructing API URLs to the http client.
 func (g *GraphAPIHandler) GetDefaultBaseDomain() string {
-	return DefaultBaseDomain
+	return g.GetCloudEndpoints().ResourceDomain
 }
 
 // GetOAuthTokenEndpoint returns the endpoint for obtaining an OAuth token. Used for constructing API URLs for the http client.
@@ -25,9 +93,9 @@ func (g *GraphAPIHandler) GetOAuthTokenEndpoint() string {
 	return OAuthTokenEndpoint
 }
 
-// GetOAuthTokenScope returns the scope for the OAuth token scope
+// GetOAuthTokenScope returns the scope for the OAuth token scope of the handler's national cloud.
 func (g *GraphAPIHandler) GetOAuthTokenScope() string {
-	return OAuthTokenScope
+	return g.GetCloudEndpoints().TokenScope
 }
 
 // GetBearerTokenEndpoint returns the endpoint for obtaining a bearer token. Used for constructing API URLs for the http client.
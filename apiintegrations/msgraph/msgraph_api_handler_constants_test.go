@@ -0,0 +1,46 @@
+// apiintegrations/msgraph/msgraph_api_handler_constants_test.go
+package msgraph
+
+import (
+	"testing"
+
+	"github.com/deploymenttheory/go-api-http-client/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestNationalClouds tests that the resource host, login authority and token scope switch together with the cloud.
+func TestNationalClouds(t *testing.T) {
+	tests := []struct {
+		cloud            string
+		expectedResource string
+		expectedAuth     string
+		expectedScope    string
+	}{
+		{"", "https://graph.microsoft.com/v1.0/users", "https://login.microsoftonline.com/tenant/oauth2/v2.0/token", "https://graph.microsoft.com/.default"},
+		{"global", "https://graph.microsoft.com/v1.0/users", "https://login.microsoftonline.com/tenant/oauth2/v2.0/token", "https://graph.microsoft.com/.default"},
+		{"usgovhigh", "https://graph.microsoft.us/v1.0/users", "https://login.microsoftonline.us/tenant/oauth2/v2.0/token", "https://graph.microsoft.us/.default"},
+		{"usgovdod", "https://dod-graph.microsoft.us/v1.0/users", "https://login.microsoftonline.us/tenant/oauth2/v2.0/token", "https://dod-graph.microsoft.us/.default"},
+		{"China", "https://microsoftgraph.chinacloudapi.cn/v1.0/users", "https://login.chinacloudapi.cn/tenant/oauth2/v2.0/token", "https://microsoftgraph.chinacloudapi.cn/.default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cloud, func(t *testing.T) {
+			mockLog := mocklogger.NewMockLogger()
+			mockLog.On("Debug", mock.AnythingOfType("string"), mock.Anything).Twice()
+
+			handler := GraphAPIHandler{TenantID: "tenant", Cloud: tt.cloud, Logger: mockLog}
+
+			assert.Equal(t, tt.expectedResource, handler.ConstructAPIResourceEndpoint("/v1.0/users", mockLog))
+			assert.Equal(t, tt.expectedAuth, handler.ConstructAPIAuthEndpoint(OAuthTokenEndpoint, mockLog))
+			assert.Equal(t, tt.expectedScope, handler.GetOAuthTokenScope())
+			mockLog.AssertExpectations(t)
+		})
+	}
+}
+
+// TestLookupCloudUnsupported tests that an unknown cloud name is rejected.
+func TestLookupCloudUnsupported(t *testing.T) {
+	_, err := LookupCloud("moon")
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@
 package msgraph
 
 import (
+	"net/url"
 	"strings"
 
 	"github.com/deploymenttheory/go-api-http-client/logger"
@@ -9,22 +10,20 @@ import (
 )
 
 // GetContentTypeHeader determines the appropriate Content-Type header for a given API endpoint.
-// It attempts to find a content type that matches the endpoint prefix in the global configMap.
+// It attempts to find a content type that matches the endpoint in the endpoint configuration.
 // If a match is found and the content type is defined (not nil), it returns the specified content type.
 // If the endpoint does not match any of the predefined patterns, "application/json" is used as a fallback.
 // This method logs the decision process at various stages for debugging purposes.
 func (g *GraphAPIHandler) GetContentTypeHeader(endpoint string, log logger.Logger) string {
 	// Dynamic lookup from configuration should be the first priority
-	for key, config := range configMap {
-		if strings.HasPrefix(endpoint, key) {
-			if config.ContentType != nil {
-				g.Logger.Debug("Content-Type for endpoint found in configMap", zap.String("endpoint", endpoint), zap.String("content_type", *config.ContentType))
-				return *config.ContentType
-			}
-			g.Logger.Debug("Content-Type for endpoint is nil in configMap, handling as special case", zap.String("endpoint", endpoint))
-			// If a nil ContentType is an expected case, do not set Content-Type header.
-			return "" // Return empty to indicate no Content-Type should be set.
+	if config, ok := g.endpointConfig(endpoint); ok {
+		if config.ContentType != nil {
+			g.Logger.Debug("Content-Type for endpoint found in configMap", zap.String("endpoint", endpoint), zap.String("content_type", *config.ContentType))
+			return *config.ContentType
 		}
+		g.Logger.Debug("Content-Type for endpoint is nil in configMap, handling as special case", zap.String("endpoint", endpoint))
+		// If a nil ContentType is an expected case, do not set Content-Type header.
+		return "" // Return empty to indicate no Content-Type should be set.
 	}
 
 	// Fallback to JSON if no other match is found.
@@ -58,13 +57,63 @@ func (g *GraphAPIHandler) GetAcceptHeader() string {
 	return weightedAcceptHeader
 }
 
+// GetAcceptHeaderForEndpoint returns the Accept header for a given API endpoint. Endpoints that
+// return a specific representation, such as images or CSV, are configured in the endpoint configuration;
+// all other endpoints use the weighted Accept header.
+func (g *GraphAPIHandler) GetAcceptHeaderForEndpoint(endpoint string) string {
+	if config, ok := g.endpointConfig(endpoint); ok && config.Accept != "" {
+		return config.Accept
+	}
+	return g.GetAcceptHeader()
+}
+
 // GetAPIRequestHeaders returns a map of standard headers required for making API requests.
+// ConsistencyLevel is added for advanced queries and Prefer when preferences are configured.
 func (g *GraphAPIHandler) GetAPIRequestHeaders(endpoint string) map[string]string {
 	headers := map[string]string{
-		"Accept":        g.GetAcceptHeader(),                        // Dynamically set based on API requirements.
+		"Accept":        g.GetAcceptHeaderForEndpoint(endpoint),     // Dynamically set based on the endpoint.
 		"Content-Type":  g.GetContentTypeHeader(endpoint, g.Logger), // Dynamically set based on the endpoint.
 		"Authorization": "",                                         // To be set by the client with the actual token.
 		"User-Agent":    "go-api-http-client-msgraph-handler",       // To be set by the client, usually with application info.
 	}
+	if IsAdvancedQuery(endpoint) {
+		headers["ConsistencyLevel"] = "eventual" // Required by directory objects for advanced query capabilities.
+	}
+	if len(g.Prefer) > 0 {
+		headers["Prefer"] = strings.Join(g.Prefer, ", ")
+	}
 	return headers
 }
+
+// IsAdvancedQuery reports whether the endpoint uses advanced query capabilities of directory objects ($count,
+// $search or the endsWith operator), which Microsoft Graph only serves with ConsistencyLevel: eventual. $count is
+// recognised both as a query parameter and as a path segment, e.g. "/users/$count".
+func IsAdvancedQuery(endpoint string) bool {
+	path, rawQuery, _ := strings.Cut(endpoint, "?")
+	for _, segment := range strings.Split(path, "/") {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segment = unescaped
+		}
+		if strings.EqualFold(segment, "$count") {
+			return true
+		}
+	}
+
+	query, err := url.QueryUnescape(rawQuery)
+	if err != nil {
+		query = rawQuery
+	}
+	query = strings.ToLower(query)
+	return strings.Contains(query, "$count") || strings.Contains(query, "$search") || strings.Contains(query, "endswith(")
+}
+
+// endpointConfig returns the endpoint configuration rule matching the endpoint. The handler's Endpoints
+// are used when set, otherwise the embedded configuration.
+func (g *GraphAPIHandler) endpointConfig(endpoint string) (EndpointConfig, bool) {
+	endpoints := g.Endpoints
+	if endpoints == nil {
+		endpoints = configMap
+	}
+	_, config, ok := endpoints.Match(endpoint)
+	return config, ok
+}
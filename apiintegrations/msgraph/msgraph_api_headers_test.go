@@ -34,3 +34,44 @@ func TestGetAcceptHeader(t *testing.T) {
 	expectedHeader := "application/x-x509-ca-cert;q=0.95,application/pkix-cert;q=0.94,application/pem-certificate-chain;q=0.93,application/octet-stream;q=0.8,image/png;q=0.75,image/jpeg;q=0.74,image/*;q=0.7,application/xml;q=0.65,text/xml;q=0.64,text/xml;charset=UTF-8;q=0.63,application/json;q=0.5,text/html;q=0.5,text/plain;q=0.4,*/*;q=0.05"
 	assert.Equal(t, expectedHeader, acceptHeader, "The Accept header should correctly prioritize MIME types.")
 }
+
+// TestGetAPIRequestHeadersAdvancedQuery tests that ConsistencyLevel is added for advanced queries only.
+func TestGetAPIRequestHeadersAdvancedQuery(t *testing.T) {
+	handler := GraphAPIHandler{Logger: mocklogger.NewMockLogger()}
+	handler.Logger.(*mocklogger.MockLogger).On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	tests := []struct {
+		endpoint string
+		want     bool
+	}{
+		{"/users?$count=true", true},
+		{"/users?$search=%22displayName:al%22", true},
+		{"/users?$filter=endsWith(mail,'@contoso.com')", true},
+		{"/users?%24count=true", true},
+		{"/users/$count", true},
+		{"/groups/123/members/%24count", true},
+		{"/users/countries", false},
+		{"/users?$filter=startsWith(displayName,'a')", false},
+		{"/users", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			headers := handler.GetAPIRequestHeaders(tt.endpoint)
+			if tt.want {
+				assert.Equal(t, "eventual", headers["ConsistencyLevel"])
+			} else {
+				assert.NotContains(t, headers, "ConsistencyLevel")
+			}
+		})
+	}
+}
+
+// TestGetAPIRequestHeadersPrefer tests that configured preferences are sent in the Prefer header.
+func TestGetAPIRequestHeadersPrefer(t *testing.T) {
+	handler := GraphAPIHandler{Logger: mocklogger.NewMockLogger(), Prefer: []string{"odata.maxpagesize=100", "return=minimal"}}
+	handler.Logger.(*mocklogger.MockLogger).On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	headers := handler.GetAPIRequestHeaders("/users")
+	assert.Equal(t, "odata.maxpagesize=100, return=minimal", headers["Prefer"])
+}
@@ -0,0 +1,265 @@
+// apiintegrations/msgraph/msgraph_api_odata.go
+package msgraph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/helpers"
+)
+
+// ODataQuery builds the OData system query options of a Microsoft Graph request, such as $filter, $select
+// and $top, and produces the endpoint string passed to DoRequest. Options are always written in the same
+// order and values are percent-encoded, so the same query always produces the same endpoint.
+type ODataQuery struct {
+	filter  Filter
+	selects []string
+	expand  []string
+	orderBy []string
+	search  string
+	top     *int
+	skip    *int
+	count   bool
+}
+
+// NewODataQuery returns an empty OData query.
+func NewODataQuery() *ODataQuery {
+	return &ODataQuery{}
+}
+
+// Filter sets the $filter expression. Use the filter functions such as Eq, StartsWith and And to build it.
+func (q *ODataQuery) Filter(filter Filter) *ODataQuery {
+	q.filter = filter
+	return q
+}
+
+// Select adds properties to $select.
+func (q *ODataQuery) Select(properties ...string) *ODataQuery {
+	q.selects = append(q.selects, properties...)
+	return q
+}
+
+// Expand adds relationships to $expand.
+func (q *ODataQuery) Expand(relationships ...string) *ODataQuery {
+	q.expand = append(q.expand, relationships...)
+	return q
+}
+
+// OrderBy adds an ascending sort on a property to $orderby.
+func (q *ODataQuery) OrderBy(property string) *ODataQuery {
+	q.orderBy = append(q.orderBy, property)
+	return q
+}
+
+// OrderByDesc adds a descending sort on a property to $orderby.
+func (q *ODataQuery) OrderByDesc(property string) *ODataQuery {
+	q.orderBy = append(q.orderBy, property+" desc")
+	return q
+}
+
+// Top sets $top, the maximum number of items returned per page.
+func (q *ODataQuery) Top(n int) *ODataQuery {
+	q.top = &n
+	return q
+}
+
+// Skip sets $skip, the number of items to skip.
+func (q *ODataQuery) Skip(n int) *ODataQuery {
+	q.skip = &n
+	return q
+}
+
+// Count sets $count=true so the response includes the total number of items.
+func (q *ODataQuery) Count() *ODataQuery {
+	q.count = true
+	return q
+}
+
+// Search sets $search from one or more search terms built with SearchTerm, combined with AND.
+func (q *ODataQuery) Search(terms ...string) *ODataQuery {
+	q.search = strings.Join(terms, " AND ")
+	return q
+}
+
+// Encode returns the query string, without a leading "?".
+func (q *ODataQuery) Encode() string {
+	var options []string
+	add := func(name, value string) {
+		options = append(options, name+"="+helpers.EscapeQueryValue(value))
+	}
+
+	if len(q.selects) > 0 {
+		add("$select", strings.Join(q.selects, ","))
+	}
+	if q.filter != "" {
+		add("$filter", string(q.filter))
+	}
+	if q.search != "" {
+		add("$search", q.search)
+	}
+	if len(q.expand) > 0 {
+		add("$expand", strings.Join(q.expand, ","))
+	}
+	if len(q.orderBy) > 0 {
+		add("$orderby", strings.Join(q.orderBy, ","))
+	}
+	if q.top != nil {
+		add("$top", strconv.Itoa(*q.top))
+	}
+	if q.skip != nil {
+		add("$skip", strconv.Itoa(*q.skip))
+	}
+	if q.count {
+		add("$count", "true")
+	}
+
+	return strings.Join(options, "&")
+}
+
+// Endpoint appends the query string to an endpoint path, e.g. "/users" becomes "/users?$select=id&$top=5".
+func (q *ODataQuery) Endpoint(endpointPath string) string {
+	return helpers.AppendQuery(endpointPath, q.Encode())
+}
+
+// Filter is an OData $filter expression.
+type Filter string
+
+// GUID is a value written as an unquoted OData Guid literal, for comparisons against Edm.Guid properties.
+type GUID string
+
+// Raw returns a filter from a pre-built expression. It is not escaped.
+func Raw(expression string) Filter {
+	return Filter(expression)
+}
+
+// Eq returns a "property eq value" comparison.
+func Eq(property string, value interface{}) Filter {
+	return compare(property, "eq", value)
+}
+
+// Ne returns a "property ne value" comparison.
+func Ne(property string, value interface{}) Filter {
+	return compare(property, "ne", value)
+}
+
+// Gt returns a "property gt value" comparison.
+func Gt(property string, value interface{}) Filter {
+	return compare(property, "gt", value)
+}
+
+// Ge returns a "property ge value" comparison.
+func Ge(property string, value interface{}) Filter {
+	return compare(property, "ge", value)
+}
+
+// Lt returns a "property lt value" comparison.
+func Lt(property string, value interface{}) Filter {
+	return compare(property, "lt", value)
+}
+
+// Le returns a "property le value" comparison.
+func Le(property string, value interface{}) Filter {
+	return compare(property, "le", value)
+}
+
+// In returns a "property in (value, ...)" comparison.
+func In(property string, values ...interface{}) Filter {
+	literals := make([]string, len(values))
+	for i, value := range values {
+		literals[i] = FormatLiteral(value)
+	}
+	return Filter(fmt.Sprintf("%s in (%s)", property, strings.Join(literals, ",")))
+}
+
+// StartsWith returns a "startswith(property,'value')" function call.
+func StartsWith(property, value string) Filter {
+	return Filter(fmt.Sprintf("startswith(%s,%s)", property, FormatLiteral(value)))
+}
+
+// EndsWith returns an "endswith(property,'value')" function call. Graph requires $count and
+// ConsistencyLevel: eventual for endswith, which the handler adds for such requests.
+func EndsWith(property, value string) Filter {
+	return Filter(fmt.Sprintf("endswith(%s,%s)", property, FormatLiteral(value)))
+}
+
+// Any returns a lambda expression over a collection, e.g. Any("assignedLicenses", "x", Eq("x/skuId", GUID(id))).
+func Any(collection, variable string, filter Filter) Filter {
+	return Filter(fmt.Sprintf("%s/any(%s:%s)", collection, variable, filter))
+}
+
+// And combines filters with "and".
+func And(filters ...Filter) Filter {
+	return Filter(strings.Join(nonEmpty(filters), " and "))
+}
+
+// Or combines filters with "or". The group is parenthesised so it keeps its precedence when combined with And.
+// Without any non-empty filters it returns an empty filter.
+func Or(filters ...Filter) Filter {
+	parts := nonEmpty(filters)
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return Filter(parts[0])
+	}
+	return Filter("(" + strings.Join(parts, " or ") + ")")
+}
+
+// Not negates a filter.
+func Not(filter Filter) Filter {
+	return Filter(fmt.Sprintf("not(%s)", filter))
+}
+
+// SearchTerm returns a quoted "property:value" $search clause, escaping quotes and backslashes in the value.
+func SearchTerm(property, value string) string {
+	return fmt.Sprintf(`"%s:%s"`, property, helpers.EscapeQuotes(value))
+}
+
+// FormatLiteral formats a Go value as an OData literal. Strings are single quoted with embedded quotes
+// doubled, times are written as Edm.DateTimeOffset in UTC, and GUID values are written unquoted.
+func FormatLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case GUID:
+		return string(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case int:
+		return strconv.Itoa(v)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case fmt.Stringer:
+		return FormatLiteral(v.String())
+	default:
+		return FormatLiteral(fmt.Sprint(v))
+	}
+}
+
+// compare returns a binary comparison with the value formatted as a literal.
+func compare(property, operator string, value interface{}) Filter {
+	return Filter(fmt.Sprintf("%s %s %s", property, operator, FormatLiteral(value)))
+}
+
+// nonEmpty returns the non-empty filters as strings.
+func nonEmpty(filters []Filter) []string {
+	parts := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		if filter != "" {
+			parts = append(parts, string(filter))
+		}
+	}
+	return parts
+}
@@ -0,0 +1,91 @@
+// apiintegrations/msgraph/msgraph_api_odata_test.go
+package msgraph
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFormatLiteral tests the FormatLiteral function.
+func TestFormatLiteral(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{"String", "Contoso", "'Contoso'"},
+		{"String with quote", "O'Brien", "'O''Brien'"},
+		{"Bool", true, "true"},
+		{"Int", 42, "42"},
+		{"Float", 1.5, "1.5"},
+		{"Nil", nil, "null"},
+		{"GUID", GUID("6f9b1b4c-0000-4000-8000-000000000000"), "6f9b1b4c-0000-4000-8000-000000000000"},
+		{"Time", time.Date(2024, 5, 1, 10, 0, 0, 0, time.FixedZone("", 2*3600)), "2024-05-01T08:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatLiteral(tt.value))
+		})
+	}
+}
+
+// TestFilterExpressions tests the filter functions.
+func TestFilterExpressions(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected string
+	}{
+		{"StartsWith escapes quotes", StartsWith("displayName", "O'Brien"), "startswith(displayName,'O''Brien')"},
+		{"EndsWith", EndsWith("mail", "@contoso.com"), "endswith(mail,'@contoso.com')"},
+		{"And with Or", And(Eq("accountEnabled", true), Or(Eq("city", "Paris"), Eq("city", "Lyon"))), "accountEnabled eq true and (city eq 'Paris' or city eq 'Lyon')"},
+		{"Or of one filter", Or(Eq("city", "Paris")), "city eq 'Paris'"},
+		{"Or of no filters", Or(), ""},
+		{"Or of empty filters", And(Eq("a", 1), Or("", "")), "a eq 1"},
+		{"Not", Not(Ne("userType", "Guest")), "not(userType ne 'Guest')"},
+		{"In", In("department", "Sales", "R&D"), "department in ('Sales','R&D')"},
+		{"Any", Any("assignedLicenses", "x", Eq("x/skuId", GUID("184efa21"))), "assignedLicenses/any(x:x/skuId eq 184efa21)"},
+		{"Empty filters are skipped", And(Eq("a", 1), "", Ge("b", 2)), "a eq 1 and b ge 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, string(tt.filter))
+		})
+	}
+}
+
+// TestODataQueryEndpoint tests that the query options are encoded in a fixed order and decode to the expected values.
+func TestODataQueryEndpoint(t *testing.T) {
+	endpoint := NewODataQuery().
+		Top(10).
+		Filter(StartsWith("displayName", "O'Brien")).
+		Select("id", "displayName").
+		OrderByDesc("createdDateTime").
+		Expand("manager").
+		Skip(20).
+		Count().
+		Search(SearchTerm("displayName", `say "hi"`)).
+		Endpoint("/users")
+
+	assert.Equal(t, "/users?$select=id%2CdisplayName&$filter=startswith%28displayName%2C%27O%27%27Brien%27%29&$search=%22displayName%3Asay%20%5C%22hi%5C%22%22&$expand=manager&$orderby=createdDateTime%20desc&$top=10&$skip=20&$count=true", endpoint)
+
+	parsed, err := url.Parse(endpoint)
+	require.NoError(t, err)
+	query := parsed.Query()
+	assert.Equal(t, "startswith(displayName,'O''Brien')", query.Get("$filter"))
+	assert.Equal(t, `"displayName:say \"hi\""`, query.Get("$search"))
+	assert.Equal(t, "createdDateTime desc", query.Get("$orderby"))
+	assert.True(t, IsAdvancedQuery(endpoint), "$count and $search require ConsistencyLevel: eventual")
+}
+
+// TestODataQueryEndpointExistingQuery tests that the query is appended to an endpoint that already has one.
+func TestODataQueryEndpointExistingQuery(t *testing.T) {
+	assert.Equal(t, "/users?api=1&$top=5", NewODataQuery().Top(5).Endpoint("/users?api=1"))
+	assert.Equal(t, "/users", NewODataQuery().Endpoint("/users"))
+}
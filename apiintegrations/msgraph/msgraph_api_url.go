@@ -3,33 +3,100 @@ package msgraph
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/deploymenttheory/go-api-http-client/helpers"
 	"github.com/deploymenttheory/go-api-http-client/logger"
 	"go.uber.org/zap"
 )
 
-// SetBaseDomain returns the appropriate base domain for URL construction. It uses DefaultBaseDomain constant.
+// SetBaseDomain returns the appropriate base domain for URL construction.
+// It uses g.OverrideBaseDomain if set, otherwise falls back to the Graph host of the national cloud.
 func (g *GraphAPIHandler) SetBaseDomain() string {
-	return DefaultBaseDomain
+	if g.OverrideBaseDomain != "" {
+		return g.OverrideBaseDomain
+	}
+	return g.GetCloudEndpoints().ResourceDomain
+}
+
+// SetBaseURL returns the base URL for resource endpoints. It uses g.BaseURL if set, otherwise the base domain.
+func (g *GraphAPIHandler) SetBaseURL() string {
+	if g.BaseURL != "" {
+		return g.BaseURL
+	}
+	return "https://" + g.SetBaseDomain()
+}
+
+// SetAuthBaseURL returns the base URL of the login authority. It uses g.AuthBaseURL if set, otherwise the
+// login authority of the national cloud. The tenant ID is appended to this URL when constructing endpoints.
+func (g *GraphAPIHandler) SetAuthBaseURL() string {
+	if g.AuthBaseURL != "" {
+		return g.AuthBaseURL
+	}
+	return g.GetCloudEndpoints().LoginBaseURL
+}
+
+// GetAPIVersion returns the configured Graph API version, or DefaultAPIVersion if none is configured.
+func (g *GraphAPIHandler) GetAPIVersion() string {
+	if g.APIVersion != "" {
+		return g.APIVersion
+	}
+	return DefaultAPIVersion
+}
+
+// ResolveEndpointPath prefixes an endpoint path with the configured Graph API version. Endpoints that already
+// start with a version, such as "/beta/users", select that version for the individual request and are left as is.
+func (g *GraphAPIHandler) ResolveEndpointPath(endpointPath string) string {
+	if endpointVersion(endpointPath) != "" {
+		return endpointPath
+	}
+	return helpers.JoinURL("/"+g.GetAPIVersion(), endpointPath)
+}
+
+// endpointVersion returns the Graph API version an endpoint path starts with, or an empty string if it names none.
+func endpointVersion(endpointPath string) string {
+	for _, version := range []string{APIVersionV1, APIVersionBeta} {
+		prefix := "/" + version
+		if endpointPath == prefix || strings.HasPrefix(endpointPath, prefix+"/") || strings.HasPrefix(endpointPath, prefix+"?") {
+			return version
+		}
+	}
+	return ""
 }
 
 // ConstructAPIResourceEndpoint constructs the full URL for a graph API resource endpoint path and logs the URL.
-// It uses the base domain to construct the full URL.
+// It uses the base domain and the API version to construct the full URL.
 func (g *GraphAPIHandler) ConstructAPIResourceEndpoint(endpointPath string, log logger.Logger) string {
-	urlBaseDomain := g.SetBaseDomain()
-	url := fmt.Sprintf("https://%s%s", urlBaseDomain, endpointPath)
+	url := helpers.JoinURL(g.SetBaseURL(), g.ResolveEndpointPath(endpointPath))
 	g.Logger.Debug(fmt.Sprintf("Constructed %s API resource endpoint URL", APIName), zap.String("URL", url))
 	return url
 }
 
+// ConstructVersionedAPIResourceEndpoint constructs the full URL for a graph API resource endpoint path using the
+// given API version, "v1.0" or "beta", instead of the configured one. An empty version uses the configured one.
+// It implements apihandler.APIVersionSelector. An endpoint naming a different version than the one given is rejected.
+func (g *GraphAPIHandler) ConstructVersionedAPIResourceEndpoint(endpointPath, version string, log logger.Logger) (string, error) {
+	if version == "" {
+		return g.ConstructAPIResourceEndpoint(endpointPath, log), nil
+	}
+	if err := ValidateAPIVersion(version); err != nil {
+		return "", err
+	}
+	switch pathVersion := endpointVersion(endpointPath); pathVersion {
+	case "":
+		endpointPath = helpers.JoinURL("/"+version, endpointPath)
+	case version:
+	default:
+		return "", fmt.Errorf("endpoint %q names microsoft graph api version %s, which conflicts with the requested version %s", endpointPath, pathVersion, version)
+	}
+	return g.ConstructAPIResourceEndpoint(endpointPath, log), nil
+}
+
 // ConstructAPIAuthEndpoint constructs the full URL for the Microsoft Graph API authentication endpoint.
 // It uses the tenant ID to construct the full URL.
 func (g *GraphAPIHandler) ConstructAPIAuthEndpoint(endpointPath string, log logger.Logger) string {
-	// The base URL for the Microsoft Graph API authentication endpoint.
-	const baseURL = "https://login.microsoftonline.com"
-
-	// Construct the full URL by combining the base URL, tenant ID, and endpoint path.
-	url := fmt.Sprintf("%s/%s%s", baseURL, g.TenantID, endpointPath)
+	// Construct the full URL by combining the login authority base URL, tenant ID, and endpoint path.
+	url := helpers.JoinURL(helpers.JoinURL(g.SetAuthBaseURL(), g.TenantID), endpointPath)
 
 	// Log the constructed URL for debugging purposes.
 	log.Debug("constructed Microsoft Graph API authentication URL", zap.String("URL", url))
@@ -53,3 +53,76 @@ func TestConstructAPIAuthEndpoint(t *testing.T) {
 	assert.Equal(t, expectedURL, resultURL, "URL should match expected format")
 	mockLog.AssertExpectations(t)
 }
+
+// TestConstructEndpointsWithBaseURLOverrides tests that BaseURL and AuthBaseURL replace the default hosts.
+func TestConstructEndpointsWithBaseURLOverrides(t *testing.T) {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Debug", mock.AnythingOfType("string"), mock.Anything).Twice()
+
+	handler := GraphAPIHandler{
+		TenantID:    "dummy-tenant-id",
+		BaseURL:     "http://127.0.0.1:8080/graph/",
+		AuthBaseURL: "http://127.0.0.1:8081",
+		Logger:      mockLog,
+	}
+
+	assert.Equal(t, "http://127.0.0.1:8080/graph/v1.0/users", handler.ConstructAPIResourceEndpoint("/v1.0/users", mockLog))
+	assert.Equal(t, "http://127.0.0.1:8081/dummy-tenant-id/oauth2/v2.0/token", handler.ConstructAPIAuthEndpoint("/oauth2/v2.0/token", mockLog))
+	mockLog.AssertExpectations(t)
+}
+
+// TestResolveEndpointPath tests that the configured API version is prefixed unless the endpoint names a version.
+func TestResolveEndpointPath(t *testing.T) {
+	tests := []struct {
+		name         string
+		apiVersion   string
+		endpointPath string
+		expected     string
+	}{
+		{"Default version", "", "/users", "/v1.0/users"},
+		{"Client version", "beta", "/users", "/beta/users"},
+		{"Request version overrides client version", "beta", "/v1.0/users", "/v1.0/users"},
+		{"Request beta version", "", "/beta/users?$top=5", "/beta/users?$top=5"},
+		{"Version-like resource", "", "/betaFeatures", "/v1.0/betaFeatures"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := GraphAPIHandler{APIVersion: tt.apiVersion}
+			assert.Equal(t, tt.expected, handler.ResolveEndpointPath(tt.endpointPath))
+		})
+	}
+}
+
+// TestConstructVersionedAPIResourceEndpoint tests that a request selects the API version instead of the client's.
+func TestConstructVersionedAPIResourceEndpoint(t *testing.T) {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Debug", mock.AnythingOfType("string"), mock.Anything)
+	handler := GraphAPIHandler{Logger: mockLog}
+
+	tests := []struct {
+		name         string
+		version      string
+		endpointPath string
+		expected     string
+		wantErr      bool
+	}{
+		{"Client version", "", "/users", "https://graph.microsoft.com/v1.0/users", false},
+		{"Request beta version", "beta", "/users", "https://graph.microsoft.com/beta/users", false},
+		{"Matching endpoint version", "beta", "/beta/users", "https://graph.microsoft.com/beta/users", false},
+		{"Conflicting endpoint version", "beta", "/v1.0/users", "", true},
+		{"Unsupported version", "v2.0", "/users", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, err := handler.ConstructVersionedAPIResourceEndpoint(tt.endpointPath, tt.version, mockLog)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, url)
+		})
+	}
+}
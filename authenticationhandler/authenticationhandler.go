@@ -18,17 +18,36 @@ type AuthTokenHandler struct {
 	AuthMethod        string            // AuthMethod specifies the method of authentication, e.g., "bearer" or "oauth".
 	InstanceName      string            // InstanceName represents the name of the instance or environment the client is interacting with.
 	tokenLock         sync.Mutex        // tokenLock ensures thread-safe access to the token and its expiry to prevent concurrent write/read issues.
+	refreshLock       sync.Mutex        // refreshLock serialises token acquisition so concurrent requests and credential updates do not race.
+	updateLock        sync.Mutex        // updateLock serialises credential updates.
 	HideSensitiveData bool
+
+	// OnRefreshTokenRotated, if set, is called with each refresh token the OAuth token endpoint issues in place of the
+	// one exchanged, so that it can be stored for the next start; providers such as GitHub revoke the previous one.
+	OnRefreshTokenRotated func(refreshToken string)
+
+	refreshToken            string // refreshToken is the OAuth refresh token exchanged for the next access token.
+	gitHubAppInstallationID int64  // gitHubAppInstallationID caches the installation ID resolved from the configured owner or repository.
 }
 
 // ClientCredentials holds the credentials necessary for authentication.
 type ClientCredentials struct {
-	Username     string
-	Password     string
-	ClientID     string
-	ClientSecret string
+	Username                        string
+	Password                        string
+	ClientID                        string
+	ClientSecret                    string
+	GitHubAppID                     string // GitHubAppID is the app ID (or client ID) used as the issuer of the app JWT.
+	GitHubAppPrivateKey             string // GitHubAppPrivateKey is the PEM encoded private key used to sign the app JWT.
+	GitHubAppInstallationID         int64  // GitHubAppInstallationID is the installation to authenticate as; looked up when zero.
+	GitHubAppInstallationOwner      string // GitHubAppInstallationOwner is the organization or user whose installation is looked up.
+	GitHubAppInstallationRepository string // GitHubAppInstallationRepository narrows the installation lookup to a repository of the owner.
+	RefreshToken                    string // RefreshToken is exchanged for OAuth access tokens instead of using the client credentials grant.
 }
 
+// DefaultTokenLifetime is how long a token issued without an expiry, such as an OAuth token whose response has no
+// expires_in, is used before it is acquired again, so that a revoked token is not sent forever.
+const DefaultTokenLifetime = time.Hour
+
 // TokenResponse represents the structure of a token response from the API.
 type TokenResponse struct {
 	Token   string    `json:"token"`
@@ -43,5 +62,21 @@ func NewAuthTokenHandler(logger logger.Logger, authMethod string, credentials Cl
 		Credentials:       credentials,
 		InstanceName:      instanceName,
 		HideSensitiveData: hideSensitiveData,
+		refreshToken:      credentials.RefreshToken,
 	}
 }
+
+// GetToken returns the current authentication token.
+func (h *AuthTokenHandler) GetToken() string {
+	h.tokenLock.Lock()
+	defer h.tokenLock.Unlock()
+	return h.Token
+}
+
+// setToken stores a newly obtained authentication token and its expiry.
+func (h *AuthTokenHandler) setToken(token string, expires time.Time) {
+	h.tokenLock.Lock()
+	defer h.tokenLock.Unlock()
+	h.Token = token
+	h.Expires = expires
+}
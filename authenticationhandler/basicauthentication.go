@@ -7,9 +7,11 @@ package authenticationhandler
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
 	"github.com/deploymenttheory/go-api-http-client/apiintegrations/apihandler"
 	"go.uber.org/zap"
 )
@@ -41,30 +43,25 @@ func (h *AuthTokenHandler) BasicAuthTokenAcquisition(apiHandler apihandler.APIHa
 
 	if resp.StatusCode != http.StatusOK {
 		h.Logger.LogError("token_authentication_failed", "POST", authenticationEndpoint, resp.StatusCode, resp.Status, fmt.Errorf("authentication failed with status code: %d", resp.StatusCode), "Token acquisition attempt resulted in a non-OK response")
-		return fmt.Errorf("received non-OK response status: %d", resp.StatusCode)
+		return apierrors.WithStatusCode(fmt.Errorf("received non-OK response status: %d", resp.StatusCode), resp.StatusCode)
 	}
 
-	tokenResp := &TokenResponse{}
-	err = json.NewDecoder(resp.Body).Decode(tokenResp)
+	tokenResp, err := decodeTokenResponse(apiHandler, resp.Body)
 	if err != nil {
 		h.Logger.Error("Failed to decode token response", zap.Error(err))
 		return err
 	}
 
-	h.Token = tokenResp.Token
-	h.Expires = tokenResp.Expires
-	tokenDuration := time.Until(h.Expires)
+	h.setToken(tokenResp.Token, tokenResp.Expires)
+	tokenDuration := time.Until(tokenResp.Expires)
 
-	h.Logger.Info("Token obtained successfully", zap.Time("Expiry", h.Expires), zap.Duration("Duration", tokenDuration))
+	h.Logger.Info("Token obtained successfully", zap.Time("Expiry", tokenResp.Expires), zap.Duration("Duration", tokenDuration))
 
 	return nil
 }
 
 // RefreshBearerToken refreshes the current authentication token.
 func (h *AuthTokenHandler) RefreshBearerToken(apiHandler apihandler.APIHandler, httpClient *http.Client) error {
-	h.tokenLock.Lock()
-	defer h.tokenLock.Unlock()
-
 	// Use the APIHandler's method to get the token refresh endpoint
 	apiTokenRefreshEndpoint := apiHandler.GetTokenRefreshEndpoint()
 
@@ -78,7 +75,7 @@ func (h *AuthTokenHandler) RefreshBearerToken(apiHandler apihandler.APIHandler,
 		h.Logger.Error("Failed to create new request for token refresh", zap.Error(err))
 		return err
 	}
-	req.Header.Add("Authorization", "Bearer "+h.Token)
+	req.Header.Add("Authorization", "Bearer "+h.GetToken())
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
@@ -89,19 +86,43 @@ func (h *AuthTokenHandler) RefreshBearerToken(apiHandler apihandler.APIHandler,
 
 	if resp.StatusCode != http.StatusOK {
 		h.Logger.Warn("Token refresh response status is not OK", zap.Int("StatusCode", resp.StatusCode))
-		return fmt.Errorf("token refresh failed with status code: %d", resp.StatusCode)
+		return apierrors.WithStatusCode(fmt.Errorf("token refresh failed with status code: %d", resp.StatusCode), resp.StatusCode)
 	}
 
-	tokenResp := &TokenResponse{}
-	err = json.NewDecoder(resp.Body).Decode(tokenResp)
+	tokenResp, err := decodeTokenResponse(apiHandler, resp.Body)
 	if err != nil {
 		h.Logger.Error("Failed to decode token response", zap.Error(err))
 		return err
 	}
 
-	h.Token = tokenResp.Token
-	h.Expires = tokenResp.Expires
+	h.setToken(tokenResp.Token, tokenResp.Expires)
 	h.Logger.Info("Token refreshed successfully", zap.Time("Expiry", tokenResp.Expires))
 
 	return nil
 }
+
+// decodeTokenResponse decodes a bearer token response body. API handlers implementing apihandler.TokenResponseParser
+// parse the body themselves; otherwise the body is decoded as a standard TokenResponse.
+func decodeTokenResponse(apiHandler apihandler.APIHandler, body io.Reader) (*TokenResponse, error) {
+	parser, ok := apiHandler.(apihandler.TokenResponseParser)
+	if !ok {
+		tokenResp := &TokenResponse{}
+		err := json.NewDecoder(body).Decode(tokenResp)
+		return tokenResp, err
+	}
+
+	bodyBytes, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	token, expires, err := parser.ParseTokenResponse(bodyBytes)
+	if err != nil {
+		return nil, err
+	}
+	if expires.IsZero() {
+		expires = time.Now().Add(DefaultTokenLifetime)
+	}
+
+	return &TokenResponse{Token: token, Expires: expires}, nil
+}
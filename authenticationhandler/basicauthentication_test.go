@@ -0,0 +1,49 @@
+// authenticationhandler/basicauthentication_test.go
+package authenticationhandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testParsingAPIHandler is an APIHandler that parses its own token responses.
+type testParsingAPIHandler struct {
+	testAPIHandler
+}
+
+func (t *testParsingAPIHandler) ParseTokenResponse(body []byte) (string, time.Time, error) {
+	var response struct {
+		Data struct {
+			JWT string `json:"jwt"`
+		} `json:"data"`
+		TTL int64 `json:"ttl"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", time.Time{}, err
+	}
+	return response.Data.JWT, time.Now().Add(time.Duration(response.TTL) * time.Second), nil
+}
+
+// TestBasicAuthTokenAcquisitionTokenResponseParser verifies that API handlers implementing
+// apihandler.TokenResponseParser are used to read the token response.
+func TestBasicAuthTokenAcquisitionTokenResponseParser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"jwt":"vendor-token"},"ttl":600}`))
+	}))
+	defer server.Close()
+
+	apiHandler := &testParsingAPIHandler{testAPIHandler{baseURL: server.URL}}
+	handler := NewAuthTokenHandler(newTestLogger(), "basicauth", ClientCredentials{}, "", false)
+
+	err := handler.BasicAuthTokenAcquisition(apiHandler, server.Client(), "user", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "vendor-token", handler.GetToken())
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), handler.Expires, 5*time.Second)
+}
@@ -0,0 +1,260 @@
+// authenticationhandler/githubapp.go
+
+/* The http_client_auth package focuses on authentication mechanisms for an HTTP client.
+It provides structures and methods for authenticating as a GitHub App installation. */
+
+package authenticationhandler
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/apihandler"
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/github"
+	"github.com/deploymenttheory/go-api-http-client/headers/redact"
+	"go.uber.org/zap"
+)
+
+const (
+	gitHubAppJWTLifetime  = 9 * time.Minute  // GitHub rejects app JWTs that expire more than ten minutes in the future.
+	gitHubAppJWTClockSkew = 60 * time.Second // The issued-at time is backdated to allow for clock drift between client and server.
+)
+
+// GitHubInstallationTokenResponse represents the response returned when exchanging an app JWT for an installation access token.
+type GitHubInstallationTokenResponse struct {
+	Token     string    `json:"token"`      // Token is the installation access token used in subsequent requests.
+	ExpiresAt time.Time `json:"expires_at"` // ExpiresAt is the time at which the installation access token expires.
+}
+
+// gitHubInstallation represents the subset of a GitHub App installation used to resolve its ID.
+type gitHubInstallation struct {
+	ID int64 `json:"id"`
+}
+
+// GitHubAppTokenAcquisition obtains an installation access token for a GitHub App. It signs an RS256 app JWT
+// with the app's private key, resolves the installation ID from the owner or repository when one is not
+// configured, and exchanges the JWT for an installation access token.
+func (h *AuthTokenHandler) GitHubAppTokenAcquisition(apiHandler apihandler.APIHandler, httpClient *http.Client, clientCredentials ClientCredentials) error {
+	appJWT, err := GenerateGitHubAppJWT(clientCredentials.GitHubAppID, clientCredentials.GitHubAppPrivateKey, time.Now())
+	if err != nil {
+		h.Logger.Error("Failed to generate GitHub App JWT", zap.Error(err))
+		return err
+	}
+
+	installationID, err := h.LookupGitHubAppInstallationID(apiHandler, httpClient, appJWT, clientCredentials)
+	if err != nil {
+		return err
+	}
+
+	tokenEndpoint := apiHandler.ConstructAPIResourceEndpoint(fmt.Sprintf(github.AppInstallationTokenEndpoint, installationID), h.Logger)
+
+	h.Logger.Debug("Attempting to obtain GitHub App installation token", zap.String("AppID", clientCredentials.GitHubAppID), zap.Int64("InstallationID", installationID))
+
+	req, err := newGitHubAppRequest(http.MethodPost, tokenEndpoint, appJWT)
+	if err != nil {
+		h.Logger.Error("Failed to create request for GitHub App installation token", zap.Error(err))
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		h.Logger.Error("Failed to execute request for GitHub App installation token", zap.Error(err))
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		h.Logger.LogError("github_app_token_acquisition_failed", http.MethodPost, tokenEndpoint, resp.StatusCode, resp.Status, fmt.Errorf("installation token request failed with status code: %d", resp.StatusCode), "GitHub App installation token request resulted in a non-OK response")
+		return apierrors.WithStatusCode(fmt.Errorf("received non-OK response status: %d", resp.StatusCode), resp.StatusCode)
+	}
+
+	tokenResp := &GitHubInstallationTokenResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(tokenResp); err != nil {
+		h.Logger.Error("Failed to decode GitHub App installation token response", zap.Error(err))
+		return err
+	}
+
+	if tokenResp.Token == "" {
+		h.Logger.Error("Empty installation token received")
+		return fmt.Errorf("empty installation token received")
+	}
+
+	if tokenResp.ExpiresAt.IsZero() {
+		tokenResp.ExpiresAt = time.Now().Add(github.InstallationTokenLifetime)
+	}
+
+	redactedToken := redact.RedactSensitiveHeaderData(h.HideSensitiveData, "AccessToken", tokenResp.Token)
+	h.Logger.Info("GitHub App installation token obtained successfully", zap.String("AccessToken", redactedToken), zap.Int64("InstallationID", installationID), zap.Time("ExpirationTime", tokenResp.ExpiresAt))
+
+	h.setToken(tokenResp.Token, tokenResp.ExpiresAt)
+
+	return nil
+}
+
+// LookupGitHubAppInstallationID returns the installation ID for the GitHub App. A configured installation ID is
+// used as-is; otherwise the installation is looked up for the configured repository, or for the configured owner
+// as an organization and then as a user account. Resolved IDs are cached for subsequent token acquisitions.
+func (h *AuthTokenHandler) LookupGitHubAppInstallationID(apiHandler apihandler.APIHandler, httpClient *http.Client, appJWT string, clientCredentials ClientCredentials) (int64, error) {
+	if clientCredentials.GitHubAppInstallationID > 0 {
+		return clientCredentials.GitHubAppInstallationID, nil
+	}
+
+	h.tokenLock.Lock()
+	cachedID := h.gitHubAppInstallationID
+	h.tokenLock.Unlock()
+	if cachedID > 0 {
+		return cachedID, nil
+	}
+
+	owner, repository := clientCredentials.GitHubAppInstallationOwner, clientCredentials.GitHubAppInstallationRepository
+	if owner == "" {
+		return 0, h.Logger.Error("GitHub App installation ID or installation owner must be provided")
+	}
+
+	var lookupEndpoints []string
+	if repository != "" {
+		lookupEndpoints = []string{fmt.Sprintf(github.RepoInstallationEndpoint, owner, repository)}
+	} else {
+		lookupEndpoints = []string{fmt.Sprintf(github.OrgInstallationEndpoint, owner), fmt.Sprintf(github.UserInstallationEndpoint, owner)}
+	}
+
+	for _, endpoint := range lookupEndpoints {
+		installationID, err := h.fetchGitHubAppInstallationID(apiHandler, httpClient, appJWT, endpoint)
+		if errors.Is(err, errGitHubInstallationNotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		h.tokenLock.Lock()
+		h.gitHubAppInstallationID = installationID
+		h.tokenLock.Unlock()
+
+		h.Logger.Info("GitHub App installation resolved", zap.String("Owner", owner), zap.String("Repository", repository), zap.Int64("InstallationID", installationID))
+		return installationID, nil
+	}
+
+	h.Logger.Error("GitHub App is not installed for the configured owner", zap.String("Owner", owner), zap.String("Repository", repository))
+	return 0, fmt.Errorf("github app installation not found for owner %q: %w", owner, errGitHubInstallationNotFound)
+}
+
+// errGitHubInstallationNotFound is returned when an installation lookup endpoint responds with 404 Not Found.
+var errGitHubInstallationNotFound = fmt.Errorf("github app installation %w", apierrors.ErrNotFound)
+
+// fetchGitHubAppInstallationID requests a single installation lookup endpoint and returns the installation ID.
+func (h *AuthTokenHandler) fetchGitHubAppInstallationID(apiHandler apihandler.APIHandler, httpClient *http.Client, appJWT, endpoint string) (int64, error) {
+	lookupURL := apiHandler.ConstructAPIResourceEndpoint(endpoint, h.Logger)
+
+	req, err := newGitHubAppRequest(http.MethodGet, lookupURL, appJWT)
+	if err != nil {
+		h.Logger.Error("Failed to create request for GitHub App installation lookup", zap.Error(err))
+		return 0, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		h.Logger.Error("Failed to execute request for GitHub App installation lookup", zap.Error(err))
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		h.Logger.Debug("GitHub App installation not found at lookup endpoint", zap.String("URL", lookupURL))
+		return 0, errGitHubInstallationNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		h.Logger.LogError("github_app_installation_lookup_failed", http.MethodGet, lookupURL, resp.StatusCode, resp.Status, fmt.Errorf("installation lookup failed with status code: %d", resp.StatusCode), "GitHub App installation lookup resulted in a non-OK response")
+		return 0, apierrors.WithStatusCode(fmt.Errorf("received non-OK response status: %d", resp.StatusCode), resp.StatusCode)
+	}
+
+	installation := &gitHubInstallation{}
+	if err := json.NewDecoder(resp.Body).Decode(installation); err != nil {
+		h.Logger.Error("Failed to decode GitHub App installation response", zap.Error(err))
+		return 0, err
+	}
+
+	return installation.ID, nil
+}
+
+// newGitHubAppRequest creates a request authenticated with the app JWT and the headers GitHub requires.
+func newGitHubAppRequest(method, url, appJWT string) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+appJWT)
+	req.Header.Set("Accept", github.MediaType)
+	req.Header.Set("X-GitHub-Api-Version", github.APIVersion)
+	return req, nil
+}
+
+// GenerateGitHubAppJWT creates an RS256 signed JSON Web Token identifying the GitHub App. The token is issued
+// slightly in the past to tolerate clock drift and expires within the ten minute window GitHub allows.
+func GenerateGitHubAppJWT(appID, privateKeyPEM string, now time.Time) (string, error) {
+	privateKey, err := ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-gitHubAppJWTClockSkew).Unix(),
+		"exp": now.Add(gitHubAppJWTLifetime).Unix(),
+		"iss": appID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign github app jwt: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// ParseRSAPrivateKeyFromPEM parses a PEM encoded RSA private key in either PKCS#1 or PKCS#8 form,
+// as downloaded from the GitHub App settings page.
+func ParseRSAPrivateKeyFromPEM(privateKeyPEM string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, errors.New("failed to decode PEM block containing the private key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	key, ok := parsedKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+
+	return key, nil
+}
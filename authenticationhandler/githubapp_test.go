@@ -0,0 +1,122 @@
+// authenticationhandler/githubapp_test.go
+package authenticationhandler
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testGitHubAPIHandler points the resource endpoints of an APIHandler at a test server.
+type testGitHubAPIHandler struct {
+	testAPIHandler
+}
+
+func (t *testGitHubAPIHandler) ConstructAPIResourceEndpoint(endpointPath string, log logger.Logger) string {
+	return t.baseURL + endpointPath
+}
+
+// newTestRSAKey returns a freshly generated RSA key and its PKCS#1 PEM encoding.
+func newTestRSAKey(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return key, string(keyPEM)
+}
+
+// TestGenerateGitHubAppJWT verifies that the app JWT is signed with the private key and carries the expected claims.
+func TestGenerateGitHubAppJWT(t *testing.T) {
+	key, keyPEM := newTestRSAKey(t)
+	now := time.Unix(1700000000, 0)
+
+	token, err := GenerateGitHubAppJWT("12345", keyPEM, now)
+	require.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	claimBytes, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims struct {
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+		Issuer    string `json:"iss"`
+	}
+	require.NoError(t, json.Unmarshal(claimBytes, &claims))
+	assert.Equal(t, "12345", claims.Issuer)
+	assert.Equal(t, now.Add(-gitHubAppJWTClockSkew).Unix(), claims.IssuedAt)
+	assert.Equal(t, now.Add(gitHubAppJWTLifetime).Unix(), claims.ExpiresAt)
+}
+
+// TestGenerateGitHubAppJWTInvalidKey verifies that an unparsable private key is rejected.
+func TestGenerateGitHubAppJWTInvalidKey(t *testing.T) {
+	_, err := GenerateGitHubAppJWT("12345", "not a pem key", time.Now())
+	assert.Error(t, err)
+}
+
+// TestParseRSAPrivateKeyFromPEMPKCS8 verifies that PKCS#8 encoded keys are accepted.
+func TestParseRSAPrivateKeyFromPEMPKCS8(t *testing.T) {
+	key, _ := newTestRSAKey(t)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	parsed, err := ParseRSAPrivateKeyFromPEM(string(keyPEM))
+	require.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+}
+
+// TestGitHubAppTokenAcquisition verifies that the installation is looked up from the owner and the app JWT
+// is exchanged for an installation access token.
+func TestGitHubAppTokenAcquisition(t *testing.T) {
+	_, keyPEM := newTestRSAKey(t)
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") || r.Header.Get("X-GitHub-Api-Version") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/orgs/acme/installation":
+			json.NewEncoder(w).Encode(map[string]int64{"id": 42})
+		case r.Method == http.MethodPost && r.URL.Path == "/app/installations/42/access_tokens":
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(GitHubInstallationTokenResponse{Token: "ghs_installation", ExpiresAt: expiresAt})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	apiHandler := &testGitHubAPIHandler{testAPIHandler{baseURL: server.URL}}
+	credentials := ClientCredentials{GitHubAppID: "12345", GitHubAppPrivateKey: keyPEM, GitHubAppInstallationOwner: "acme"}
+	handler := NewAuthTokenHandler(newTestLogger(), "githubapp", credentials, "", false)
+
+	valid, err := handler.CheckAndRefreshAuthToken(apiHandler, server.Client(), credentials, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, "ghs_installation", handler.GetToken())
+	assert.True(t, expiresAt.Equal(handler.Expires))
+	assert.Equal(t, int64(42), handler.gitHubAppInstallationID)
+}
@@ -15,6 +15,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
 	"github.com/deploymenttheory/go-api-http-client/apiintegrations/apihandler"
 	"github.com/deploymenttheory/go-api-http-client/headers/redact"
 	"go.uber.org/zap"
@@ -23,14 +24,16 @@ import (
 // OAuthResponse represents the response structure when obtaining an OAuth access token.
 type OAuthResponse struct {
 	AccessToken  string `json:"access_token"`            // AccessToken is the token that can be used in subsequent requests for authentication.
-	ExpiresIn    int64  `json:"expires_in"`              // ExpiresIn specifies the duration in seconds after which the access token expires.
+	ExpiresIn    int64  `json:"expires_in"`              // ExpiresIn specifies the duration in seconds after which the access token expires; zero when it does not.
 	TokenType    string `json:"token_type"`              // TokenType indicates the type of token, typically "Bearer".
 	RefreshToken string `json:"refresh_token,omitempty"` // RefreshToken is used to obtain a new access token when the current one expires.
 	Error        string `json:"error,omitempty"`         // Error contains details if an error occurs during the token acquisition process.
 }
 
 // OAuth2TokenAcquisition fetches an OAuth access token using the provided client ID and client secret.
-// It updates the AuthTokenHandler's Token and Expires fields with the obtained values.
+// It updates the AuthTokenHandler's Token and Expires fields with the obtained values. When the credentials
+// carry a refresh token it is exchanged with the refresh token grant rather than the client credentials grant,
+// and the refresh token issued in its place is kept for the next exchange.
 func (h *AuthTokenHandler) OAuth2TokenAcquisition(apiHandler apihandler.APIHandler, httpClient *http.Client, clientID, clientSecret string) error {
 	// Get the OAuth token endpoint from the APIHandler
 	oauthTokenEndpoint := apiHandler.GetOAuthTokenEndpoint()
@@ -44,10 +47,16 @@ func (h *AuthTokenHandler) OAuth2TokenAcquisition(apiHandler apihandler.APIHandl
 	data := url.Values{}
 	data.Set("client_id", clientID)
 	data.Set("client_secret", clientSecret)
-	data.Set("scope", oauthTokenScope)
-	data.Set("grant_type", "client_credentials")
+	grantType := "client_credentials"
+	if h.refreshToken != "" {
+		grantType = "refresh_token"
+		data.Set("refresh_token", h.refreshToken)
+	} else {
+		data.Set("scope", oauthTokenScope)
+	}
+	data.Set("grant_type", grantType)
 
-	h.Logger.Debug("Attempting to obtain OAuth token", zap.String("ClientID", clientID), zap.String("Scope", oauthTokenScope))
+	h.Logger.Debug("Attempting to obtain OAuth token", zap.String("ClientID", clientID), zap.String("GrantType", grantType), zap.String("Scope", oauthTokenScope))
 
 	req, err := http.NewRequest("POST", authenticationEndpoint, strings.NewReader(data.Encode()))
 	if err != nil {
@@ -55,6 +64,8 @@ func (h *AuthTokenHandler) OAuth2TokenAcquisition(apiHandler apihandler.APIHandl
 		return err
 	}
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	// Some providers return a form encoded token response unless JSON is requested explicitly.
+	req.Header.Add("Accept", "application/json")
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
@@ -72,16 +83,34 @@ func (h *AuthTokenHandler) OAuth2TokenAcquisition(apiHandler apihandler.APIHandl
 	// Reset the response body to its original state
 	resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 
+	// API handlers with non-standard token responses extract the token and expiry themselves
+	if parser, ok := apiHandler.(apihandler.TokenResponseParser); ok {
+		accessToken, expirationTime, err := parser.ParseTokenResponse(bodyBytes)
+		if err != nil {
+			h.Logger.Error("Failed to parse OAuth response", zap.Error(err))
+			return apierrors.WithStatusCode(err, resp.StatusCode)
+		}
+		if expirationTime.IsZero() {
+			expirationTime = time.Now().Add(DefaultTokenLifetime)
+		}
+
+		redactedAccessToken := redact.RedactSensitiveHeaderData(h.HideSensitiveData, "AccessToken", accessToken)
+		h.Logger.Info("OAuth token obtained successfully", zap.String("AccessToken", redactedAccessToken), zap.Time("ExpirationTime", expirationTime))
+
+		h.setToken(accessToken, expirationTime)
+		return nil
+	}
+
 	oauthResp := &OAuthResponse{}
 	err = json.Unmarshal(bodyBytes, oauthResp)
 	if err != nil {
 		h.Logger.Error("Failed to decode OAuth response", zap.Error(err))
-		return err
+		return apierrors.WithStatusCode(err, resp.StatusCode)
 	}
 
 	if oauthResp.Error != "" {
 		h.Logger.Error("Error obtaining OAuth token", zap.String("Error", oauthResp.Error))
-		return fmt.Errorf("error obtaining OAuth token: %s", oauthResp.Error)
+		return apierrors.WithStatusCode(fmt.Errorf("error obtaining OAuth token: %s", oauthResp.Error), resp.StatusCode)
 	}
 
 	if oauthResp.AccessToken == "" {
@@ -89,15 +118,27 @@ func (h *AuthTokenHandler) OAuth2TokenAcquisition(apiHandler apihandler.APIHandl
 		return fmt.Errorf("empty access token received")
 	}
 
+	// expires_in is optional; a token issued without it is acquired again after the default lifetime
 	expiresIn := time.Duration(oauthResp.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = DefaultTokenLifetime
+	}
 	expirationTime := time.Now().Add(expiresIn)
 
 	// Modified log call using the helper function
 	redactedAccessToken := redact.RedactSensitiveHeaderData(h.HideSensitiveData, "AccessToken", oauthResp.AccessToken)
 	h.Logger.Info("OAuth token obtained successfully", zap.String("AccessToken", redactedAccessToken), zap.Duration("ExpiresIn", expiresIn), zap.Time("ExpirationTime", expirationTime))
 
-	h.Token = oauthResp.AccessToken
-	h.Expires = expirationTime
+	h.setToken(oauthResp.AccessToken, expirationTime)
+
+	// Providers rotating refresh tokens, such as GitHub, revoke the one just exchanged
+	if grantType == "refresh_token" && oauthResp.RefreshToken != "" && oauthResp.RefreshToken != h.refreshToken {
+		h.refreshToken = oauthResp.RefreshToken
+		h.Logger.Info("OAuth refresh token rotated")
+		if h.OnRefreshTokenRotated != nil {
+			h.OnRefreshTokenRotated(oauthResp.RefreshToken)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,142 @@
+// authenticationhandler/oauth2_test.go
+package authenticationhandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/apihandler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testOAuthAPIHandler points the OAuth token endpoint of an APIHandler at a test server.
+type testOAuthAPIHandler struct {
+	testAPIHandler
+}
+
+func (t *testOAuthAPIHandler) GetOAuthTokenEndpoint() string { return "/login/oauth/access_token" }
+func (t *testOAuthAPIHandler) GetOAuthTokenScope() string    { return "" }
+
+// TestOAuth2TokenAcquisition verifies that the token request asks for a JSON response, as some providers otherwise
+// return a form encoded body, and that the token and expiry are stored.
+func TestOAuth2TokenAcquisition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/json" {
+			w.Header().Set("Content-Type", "application/x-www-form-urlencoded")
+			w.Write([]byte("access_token=gho_form&token_type=bearer"))
+			return
+		}
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client-id", r.PostForm.Get("client_id"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"gho_json","token_type":"bearer","expires_in":28800}`))
+	}))
+	defer server.Close()
+
+	apiHandler := &testOAuthAPIHandler{testAPIHandler{baseURL: server.URL}}
+	handler := NewAuthTokenHandler(newTestLogger(), "oauth2", ClientCredentials{}, "", false)
+
+	before := time.Now()
+	err := handler.OAuth2TokenAcquisition(apiHandler, server.Client(), "client-id", "client-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "gho_json", handler.GetToken())
+	assert.WithinDuration(t, before.Add(8*time.Hour), handler.Expires, 5*time.Second)
+}
+
+// TestOAuth2TokenWithoutExpiry verifies that a token response without expires_in yields a token that is used for the
+// default lifetime, so that it is neither exchanged again on every request nor kept after it may have been revoked.
+func TestOAuth2TokenWithoutExpiry(t *testing.T) {
+	var exchanges int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&exchanges, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token-without-expiry","token_type":"bearer"}`))
+	}))
+	defer server.Close()
+
+	apiHandler := &testOAuthAPIHandler{testAPIHandler{baseURL: server.URL}}
+	credentials := ClientCredentials{ClientID: "client-id", ClientSecret: "client-secret"}
+	handler := NewAuthTokenHandler(newTestLogger(), "oauth2", credentials, "", false)
+
+	for i := 0; i < 3; i++ {
+		valid, err := handler.CheckAndRefreshAuthToken(apiHandler, server.Client(), credentials, 5*time.Minute)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	}
+	assert.Equal(t, "token-without-expiry", handler.GetToken())
+	assert.WithinDuration(t, time.Now().Add(DefaultTokenLifetime), handler.Expires, 5*time.Second)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&exchanges))
+}
+
+// TestOAuth2RefreshTokenGrant verifies that credentials with a refresh token are exchanged with the refresh token
+// grant, as GitHub requires, and that the rotated refresh token is used for the next exchange and reported.
+func TestOAuth2RefreshTokenGrant(t *testing.T) {
+	var exchanges int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		assert.Empty(t, r.PostForm.Get("scope"))
+		n := atomic.AddInt32(&exchanges, 1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("refresh_token") != fmt.Sprintf("ghr_%d", n) {
+			// GitHub reports a revoked refresh token with a 200 response
+			w.Write([]byte(`{"error":"bad_refresh_token","error_description":"The refresh token passed is incorrect or expired."}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  fmt.Sprintf("ghu_%d", n),
+			"expires_in":    60,
+			"refresh_token": fmt.Sprintf("ghr_%d", n+1),
+			"token_type":    "bearer",
+		})
+	}))
+	defer server.Close()
+
+	apiHandler := &testOAuthAPIHandler{testAPIHandler{baseURL: server.URL}}
+	credentials := ClientCredentials{ClientID: "Iv23liAbCdEf", ClientSecret: "secret", RefreshToken: "ghr_1"}
+	handler := NewAuthTokenHandler(newTestLogger(), "oauth2", credentials, "", false)
+	var rotated []string
+	handler.OnRefreshTokenRotated = func(refreshToken string) { rotated = append(rotated, refreshToken) }
+
+	// The token expires within the buffer period, so every check exchanges the current refresh token
+	for i := 1; i <= 2; i++ {
+		_, err := handler.CheckAndRefreshToken(apiHandler, server.Client(), time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("ghu_%d", atomic.LoadInt32(&exchanges)), handler.GetToken())
+	}
+	assert.Equal(t, []string{"ghr_2", "ghr_3", "ghr_4", "ghr_5"}, rotated)
+
+	handler.refreshToken = "ghr_revoked"
+	_, err := handler.CheckAndRefreshToken(apiHandler, server.Client(), time.Minute)
+	assert.ErrorContains(t, err, "bad_refresh_token")
+}
+
+// TestOAuth2TokenAcquisitionGenericDefaults verifies that the generic api handler, configured without token response
+// fields, reads a standard OAuth 2.0 token response.
+func TestOAuth2TokenAcquisitionGenericDefaults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"standard-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	options, err := json.Marshal(map[string]interface{}{
+		"base_url":             server.URL,
+		"oauth_token_endpoint": "/oauth/token",
+		"auth_modes":           []string{"oauth2"},
+	})
+	require.NoError(t, err)
+	apiHandler, err := apihandler.NewAPIHandler(apihandler.HandlerConfig{APIType: "generic", Options: options, Logger: newTestLogger()})
+	require.NoError(t, err)
+
+	handler := NewAuthTokenHandler(newTestLogger(), "oauth2", ClientCredentials{}, "", false)
+	require.NoError(t, handler.OAuth2TokenAcquisition(apiHandler, server.Client(), "client-id", "client-secret"))
+	assert.Equal(t, "standard-token", handler.GetToken())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), handler.Expires, 5*time.Second)
+}
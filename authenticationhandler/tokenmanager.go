@@ -13,6 +13,22 @@ import (
 // CheckAndRefreshAuthToken checks the token's validity and refreshes it if necessary.
 // It returns true if the token is valid post any required operations and false with an error otherwise.
 func (h *AuthTokenHandler) CheckAndRefreshAuthToken(apiHandler apihandler.APIHandler, httpClient *http.Client, clientCredentials ClientCredentials, tokenRefreshBufferPeriod time.Duration) (bool, error) {
+	h.refreshLock.Lock()
+	defer h.refreshLock.Unlock()
+	return h.checkAndRefreshAuthToken(apiHandler, httpClient, clientCredentials, tokenRefreshBufferPeriod)
+}
+
+// CheckAndRefreshToken checks the token's validity and refreshes it if necessary with the credentials held by the
+// handler. The credentials and the authentication method are read under the same lock UpdateCredentials swaps them
+// under, so a token is never acquired with credentials that do not belong to the method.
+func (h *AuthTokenHandler) CheckAndRefreshToken(apiHandler apihandler.APIHandler, httpClient *http.Client, tokenRefreshBufferPeriod time.Duration) (bool, error) {
+	h.refreshLock.Lock()
+	defer h.refreshLock.Unlock()
+	return h.checkAndRefreshAuthToken(apiHandler, httpClient, h.Credentials, tokenRefreshBufferPeriod)
+}
+
+// checkAndRefreshAuthToken checks and refreshes the token. The caller must hold refreshLock.
+func (h *AuthTokenHandler) checkAndRefreshAuthToken(apiHandler apihandler.APIHandler, httpClient *http.Client, clientCredentials ClientCredentials, tokenRefreshBufferPeriod time.Duration) (bool, error) {
 	if !h.isTokenValid(tokenRefreshBufferPeriod) {
 		h.Logger.Debug("Token found to be invalid or close to expiry, handling token acquisition or refresh.")
 		if err := h.obtainNewToken(apiHandler, httpClient, clientCredentials); err != nil {
@@ -34,8 +50,12 @@ func (h *AuthTokenHandler) CheckAndRefreshAuthToken(apiHandler apihandler.APIHan
 // isTokenValid checks if the current token is non-empty and not about to expire.
 // It considers a token valid if it exists and the time until its expiration is greater than the provided buffer period.
 func (h *AuthTokenHandler) isTokenValid(tokenRefreshBufferPeriod time.Duration) bool {
-	isValid := h.Token != "" && time.Until(h.Expires) >= tokenRefreshBufferPeriod
-	h.Logger.Debug("Checking token validity", zap.Bool("IsValid", isValid), zap.Duration("TimeUntilExpiry", time.Until(h.Expires)))
+	h.tokenLock.Lock()
+	token, timeUntilExpiry := h.Token, time.Until(h.Expires)
+	h.tokenLock.Unlock()
+
+	isValid := token != "" && timeUntilExpiry >= tokenRefreshBufferPeriod
+	h.Logger.Debug("Checking token validity", zap.Bool("IsValid", isValid), zap.Duration("TimeUntilExpiry", timeUntilExpiry))
 	return isValid
 }
 
@@ -47,6 +67,8 @@ func (h *AuthTokenHandler) obtainNewToken(apiHandler apihandler.APIHandler, http
 		err = h.BasicAuthTokenAcquisition(apiHandler, httpClient, clientCredentials.Username, clientCredentials.Password)
 	} else if h.AuthMethod == "oauth2" {
 		err = h.OAuth2TokenAcquisition(apiHandler, httpClient, clientCredentials.ClientID, clientCredentials.ClientSecret)
+	} else if h.AuthMethod == "githubapp" {
+		err = h.GitHubAppTokenAcquisition(apiHandler, httpClient, clientCredentials)
 	} else {
 		err = fmt.Errorf("no valid credentials provided. Unable to obtain a token")
 		h.Logger.Error("Authentication method not supported", zap.String("AuthMethod", h.AuthMethod))
@@ -61,10 +83,17 @@ func (h *AuthTokenHandler) obtainNewToken(apiHandler apihandler.APIHandler, http
 // refreshTokenIfNeeded refreshes the token if it's close to expiration.
 // This function decides on the method based on the credentials type available.
 func (h *AuthTokenHandler) refreshTokenIfNeeded(apiHandler apihandler.APIHandler, httpClient *http.Client, clientCredentials ClientCredentials, tokenRefreshBufferPeriod time.Duration) error {
-	if time.Until(h.Expires) < tokenRefreshBufferPeriod {
-		h.Logger.Info("Token is close to expiry and will be refreshed", zap.Duration("TimeUntilExpiry", time.Until(h.Expires)))
+	h.tokenLock.Lock()
+	timeUntilExpiry := time.Until(h.Expires)
+	h.tokenLock.Unlock()
+
+	if timeUntilExpiry < tokenRefreshBufferPeriod {
+		h.Logger.Info("Token is close to expiry and will be refreshed", zap.Duration("TimeUntilExpiry", timeUntilExpiry))
 		var err error
-		if clientCredentials.Username != "" && clientCredentials.Password != "" {
+		if h.AuthMethod == "githubapp" {
+			// Installation tokens cannot be extended, so a new one is issued before the current one expires.
+			err = h.GitHubAppTokenAcquisition(apiHandler, httpClient, clientCredentials)
+		} else if clientCredentials.Username != "" && clientCredentials.Password != "" {
 			err = h.RefreshBearerToken(apiHandler, httpClient)
 		} else if clientCredentials.ClientID != "" && clientCredentials.ClientSecret != "" {
 			err = h.OAuth2TokenAcquisition(apiHandler, httpClient, clientCredentials.ClientID, clientCredentials.ClientSecret)
@@ -80,3 +109,39 @@ func (h *AuthTokenHandler) refreshTokenIfNeeded(apiHandler apihandler.APIHandler
 	}
 	return nil
 }
+
+// UpdateCredentials replaces the credentials and authentication method used by the handler. A token is acquired
+// with the new credentials first; only if that succeeds are the credentials, the method and the token swapped, all
+// under the lock token acquisition runs under, and commit, if not nil, is called within the same critical section so
+// callers can swap state of their own along with it. If acquisition fails the current credentials and token are kept.
+// Requests keep using the current token while the new one is acquired.
+func (h *AuthTokenHandler) UpdateCredentials(apiHandler apihandler.APIHandler, httpClient *http.Client, authMethod string, clientCredentials ClientCredentials, commit func()) error {
+	h.updateLock.Lock()
+	defer h.updateLock.Unlock()
+
+	candidate := NewAuthTokenHandler(h.Logger, authMethod, clientCredentials, h.InstanceName, h.HideSensitiveData)
+	candidate.OnRefreshTokenRotated = h.OnRefreshTokenRotated
+	if err := candidate.obtainNewToken(apiHandler, httpClient, clientCredentials); err != nil {
+		h.Logger.Error("Failed to obtain token with updated credentials, keeping the current credentials", zap.Error(err))
+		return err
+	}
+
+	h.refreshLock.Lock()
+	defer h.refreshLock.Unlock()
+
+	h.tokenLock.Lock()
+	h.AuthMethod = authMethod
+	h.Credentials = clientCredentials
+	h.Token = candidate.Token
+	h.Expires = candidate.Expires
+	h.gitHubAppInstallationID = candidate.gitHubAppInstallationID
+	h.refreshToken = candidate.refreshToken
+	h.tokenLock.Unlock()
+
+	if commit != nil {
+		commit()
+	}
+
+	h.Logger.Info("Authentication credentials updated", zap.String("AuthMethod", authMethod))
+	return nil
+}
@@ -0,0 +1,90 @@
+// authenticationhandler/tokenmanager_test.go
+package authenticationhandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/apihandler"
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"github.com/deploymenttheory/go-api-http-client/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testAPIHandler points the authentication endpoints of an APIHandler at a test server.
+// Methods that are not overridden are never called by the token manager.
+type testAPIHandler struct {
+	apihandler.APIHandler
+	baseURL string
+}
+
+func (t *testAPIHandler) GetBearerTokenEndpoint() string  { return "/api/v1/auth/token" }
+func (t *testAPIHandler) GetTokenRefreshEndpoint() string { return "/api/v1/auth/keep-alive" }
+func (t *testAPIHandler) ConstructAPIAuthEndpoint(endpointPath string, log logger.Logger) string {
+	return t.baseURL + endpointPath
+}
+
+// newTestLogger returns a mock logger that accepts any log call.
+func newTestLogger() *mocklogger.MockLogger {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Warn", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Error", mock.Anything).Return(errors.New("logged error")).Maybe()
+	mockLog.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("LogError", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+	return mockLog
+}
+
+// TestUpdateCredentials verifies that updating credentials invalidates the current token and
+// immediately acquires a new token using the new credentials.
+func TestUpdateCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, _, _ := r.BasicAuth()
+		if username == "baduser" {
+			http.Error(w, "invalid credentials", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(TokenResponse{Token: "token-" + username, Expires: time.Now().Add(time.Hour)})
+	}))
+	defer server.Close()
+
+	apiHandler := &testAPIHandler{baseURL: server.URL}
+	oldCredentials := ClientCredentials{Username: "olduser", Password: "oldpassword"}
+	handler := NewAuthTokenHandler(newTestLogger(), "basicauth", oldCredentials, "instance", false)
+
+	valid, err := handler.CheckAndRefreshAuthToken(apiHandler, server.Client(), oldCredentials, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, "token-olduser", handler.GetToken())
+
+	newCredentials := ClientCredentials{Username: "newuser", Password: "newpassword"}
+	committed := false
+	err = handler.UpdateCredentials(apiHandler, server.Client(), "basicauth", newCredentials, func() {
+		committed = true
+		assert.Equal(t, "token-newuser", handler.Token, "the token is swapped before commit is called")
+	})
+	assert.NoError(t, err)
+	assert.True(t, committed)
+	assert.Equal(t, "token-newuser", handler.GetToken())
+	assert.Equal(t, newCredentials, handler.Credentials)
+
+	// Credentials the server rejects leave the current credentials and token in place
+	badCredentials := ClientCredentials{Username: "baduser", Password: "badpassword"}
+	err = handler.UpdateCredentials(apiHandler, server.Client(), "basicauth", badCredentials, func() {
+		t.Error("commit is not called when token acquisition fails")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "token-newuser", handler.GetToken())
+	assert.Equal(t, newCredentials, handler.Credentials)
+
+	valid, err = handler.CheckAndRefreshToken(apiHandler, server.Client(), time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
@@ -60,3 +60,21 @@ func IsValidPassword(password string) (bool, string) {
 	}
 	return false, "Password must be at least 8 characters long."
 }
+
+// IsValidGitHubAppID checks if the provided GitHub App identifier is either a numeric app ID or an app client ID.
+// Returns true if valid, along with an empty error message; otherwise, returns false with an error message.
+func IsValidGitHubAppID(appID string) (bool, string) {
+	if regexp.MustCompile(`^(\d+|Iv[0-9A-Za-z.]+)$`).MatchString(appID) {
+		return true, ""
+	}
+	return false, "GitHub App ID must be a numeric app ID or an app client ID."
+}
+
+// IsValidGitHubAppPrivateKey checks if the provided GitHub App private key is a PEM encoded RSA private key.
+// Returns true if valid, along with an empty error message; otherwise, returns false with an error message.
+func IsValidGitHubAppPrivateKey(privateKeyPEM string) (bool, string) {
+	if _, err := ParseRSAPrivateKeyFromPEM(privateKeyPEM); err != nil {
+		return false, "GitHub App private key must be a PEM encoded RSA private key."
+	}
+	return true, ""
+}
@@ -92,3 +92,28 @@ func TestIsValidUsername(t *testing.T) {
 		})
 	}
 }
+
+// TestIsValidGitHubAppID tests the IsValidGitHubAppID function to ensure it accepts app IDs and app client IDs.
+func TestIsValidGitHubAppID(t *testing.T) {
+	tests := []struct {
+		name   string
+		appID  string
+		want   bool
+		errMsg string
+	}{
+		{"Valid App ID", "123456", true, ""},
+		{"Valid Client ID", "Iv23liABCdef123456", true, ""},
+		{"Invalid Characters", "app-123", false, "GitHub App ID must be a numeric app ID or an app client ID."},
+		{"Empty App ID", "", false, "GitHub App ID must be a numeric app ID or an app client ID."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, errMsg := IsValidGitHubAppID(tt.appID)
+			assert.Equal(t, tt.want, valid)
+			if !tt.want {
+				assert.Equal(t, tt.errMsg, errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// cachehandler/disk.go
+package cachehandler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DiskStore is a Store that keeps each entry as a JSON file in a directory, so cached responses survive restarts.
+type DiskStore struct {
+	dir string
+}
+
+// NewDiskStore creates a DiskStore in dir, creating the directory if it does not exist.
+func NewDiskStore(dir string) (*DiskStore, error) {
+	if dir == "" {
+		return nil, errors.New("cache directory is required for the disk store")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory %s: %w", dir, err)
+	}
+	return &DiskStore{dir: dir}, nil
+}
+
+// Get returns the entry stored under key. A missing file is a miss rather than an error.
+func (s *DiskStore) Get(key string) (*Entry, bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false, fmt.Errorf("failed to decode cache entry for %s: %w", key, err)
+	}
+	return &entry, true, nil
+}
+
+// Set stores entry under key. The file is written to a temporary name and renamed so readers never see a partial entry.
+func (s *DiskStore) Set(key string, entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+// Delete removes the entry stored under key.
+func (s *DiskStore) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path returns the file used for key. Keys are hashed as they are URLs.
+func (s *DiskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
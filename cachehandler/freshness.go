@@ -0,0 +1,157 @@
+// cachehandler/freshness.go
+package cachehandler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cacheControl holds the directives of a Cache-Control header, keyed by lower case name.
+type cacheControl map[string]string
+
+// parseCacheControl parses the Cache-Control headers of h.
+func parseCacheControl(h http.Header) cacheControl {
+	directives := cacheControl{}
+	for _, value := range h.Values("Cache-Control") {
+		for _, part := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if name == "" {
+				continue
+			}
+			directives[strings.ToLower(name)] = strings.Trim(arg, "\"")
+		}
+	}
+	return directives
+}
+
+// has reports whether the directive is present.
+func (cc cacheControl) has(name string) bool {
+	_, ok := cc[name]
+	return ok
+}
+
+// duration returns the value of a delta-seconds directive such as max-age.
+func (cc cacheControl) duration(name string) (time.Duration, bool) {
+	arg, ok := cc[name]
+	if !ok {
+		return 0, false
+	}
+	seconds, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// isStorable reports whether a response to req may be stored. Only successful GET responses without no-store are
+// kept, and only if they can be reused, either because they are fresh for a while or because they carry a validator.
+func isStorable(req *http.Request, resp *http.Response) bool {
+	if req.Method != http.MethodGet || resp.StatusCode != http.StatusOK {
+		return false
+	}
+	if parseCacheControl(req.Header).has("no-store") || parseCacheControl(resp.Header).has("no-store") {
+		return false
+	}
+	if resp.Header.Get("Vary") == "*" {
+		return false
+	}
+	return resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != "" || freshnessLifetime(resp.Header) > 0
+}
+
+// freshnessLifetime returns how long a response stays fresh after it was generated, from max-age or Expires.
+// Heuristic freshness is not used, so a response without explicit freshness is revalidated on every request.
+func freshnessLifetime(h http.Header) time.Duration {
+	cc := parseCacheControl(h)
+	if cc.has("no-cache") {
+		return 0
+	}
+	if maxAge, ok := cc.duration("max-age"); ok {
+		return maxAge
+	}
+	if expires := h.Get("Expires"); expires != "" {
+		expiresAt, err := http.ParseTime(expires)
+		if err != nil {
+			return 0
+		}
+		date, err := http.ParseTime(h.Get("Date"))
+		if err != nil {
+			return 0
+		}
+		if lifetime := expiresAt.Sub(date); lifetime > 0 {
+			return lifetime
+		}
+	}
+	return 0
+}
+
+// age returns the current age of the entry, which includes any Age reported by upstream caches when it was stored.
+func (e *Entry) age(now time.Time) time.Duration {
+	age := now.Sub(e.StoredAt)
+	if seconds, err := strconv.ParseInt(e.Header.Get("Age"), 10, 64); err == nil && seconds > 0 {
+		age += time.Duration(seconds) * time.Second
+	}
+	if age < 0 {
+		return 0
+	}
+	return age
+}
+
+// isFresh reports whether the entry can be used without contacting the server.
+func (e *Entry) isFresh(req *http.Request, now time.Time) bool {
+	requestCC := parseCacheControl(req.Header)
+	if requestCC.has("no-cache") {
+		return false
+	}
+	lifetime := freshnessLifetime(e.Header)
+	if maxAge, ok := requestCC.duration("max-age"); ok && maxAge < lifetime {
+		lifetime = maxAge
+	}
+	return e.age(now) < lifetime
+}
+
+// isUsableOnError reports whether the stale entry may be used when the server cannot be reached or returns a server
+// error. The response's stale-if-error directive takes precedence over the configured window, and must-revalidate
+// disables it.
+func (e *Entry) isUsableOnError(now time.Time, staleIfError time.Duration) bool {
+	cc := parseCacheControl(e.Header)
+	if cc.has("must-revalidate") {
+		return false
+	}
+	if window, ok := cc.duration("stale-if-error"); ok {
+		staleIfError = window
+	}
+	if staleIfError <= 0 {
+		return false
+	}
+	return e.age(now) < freshnessLifetime(e.Header)+staleIfError
+}
+
+// matchesVary reports whether req selects the entry, i.e. it sends the same values for the headers named by Vary.
+func (e *Entry) matchesVary(req *http.Request) bool {
+	for name, value := range e.VaryHeaders {
+		if req.Header.Get(name) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// varyHeaders returns the values req sends for the headers named by the response's Vary header.
+func varyHeaders(req *http.Request, resp *http.Response) map[string]string {
+	var values map[string]string
+	for _, vary := range resp.Header.Values("Vary") {
+		for _, name := range strings.Split(vary, ",") {
+			name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			if values == nil {
+				values = make(map[string]string)
+			}
+			values[name] = req.Header.Get(name)
+		}
+	}
+	return values
+}
@@ -0,0 +1,80 @@
+// cachehandler/store.go
+package cachehandler
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Entry is a cached response together with the request header values it was selected by.
+type Entry struct {
+	URL         string            `json:"url"`                    // URL of the request the response was received for
+	StatusCode  int               `json:"status_code"`            // Status code of the stored response
+	Header      http.Header       `json:"header"`                 // Response headers, updated when the response is revalidated
+	Body        []byte            `json:"body"`                   // Response body
+	StoredAt    time.Time         `json:"stored_at"`              // Time the response was received or last revalidated
+	VaryHeaders map[string]string `json:"vary_headers,omitempty"` // Request header values named by the response's Vary header
+}
+
+// Store persists cache entries by key. Implementations must be safe for concurrent use. Entries returned by Get may be
+// shared with other callers and must not be modified.
+type Store interface {
+	Get(key string) (*Entry, bool, error)
+	Set(key string, entry *Entry) error
+	Delete(key string) error
+}
+
+// MemoryStore is an in-memory Store. When full, the least recently stored entry is evicted.
+type MemoryStore struct {
+	mu         sync.Mutex
+	entries    map[string]*Entry
+	maxEntries int
+}
+
+// NewMemoryStore creates a MemoryStore holding at most maxEntries entries, or an unbounded number if maxEntries is 0 or less.
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	return &MemoryStore{
+		entries:    make(map[string]*Entry),
+		maxEntries: maxEntries,
+	}
+}
+
+// Get returns the entry stored under key.
+func (s *MemoryStore) Get(key string) (*Entry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	return entry, ok, nil
+}
+
+// Set stores entry under key, evicting the oldest entry if the store is full.
+func (s *MemoryStore) Set(key string, entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.entries[key]; !exists && s.maxEntries > 0 && len(s.entries) >= s.maxEntries {
+		s.evictOldest()
+	}
+	s.entries[key] = entry
+	return nil
+}
+
+// Delete removes the entry stored under key.
+func (s *MemoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// evictOldest removes the entry with the earliest StoredAt time. The caller must hold the lock.
+func (s *MemoryStore) evictOldest() {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range s.entries {
+		if oldestKey == "" || entry.StoredAt.Before(oldest) {
+			oldestKey, oldest = key, entry.StoredAt
+		}
+	}
+	delete(s.entries, oldestKey)
+}
@@ -0,0 +1,58 @@
+// cachehandler/store_test.go
+package cachehandler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMemoryStoreEviction tests that a full memory store evicts the oldest entry.
+func TestMemoryStoreEviction(t *testing.T) {
+	store := NewMemoryStore(2)
+	now := time.Now()
+	require.NoError(t, store.Set("a", &Entry{StoredAt: now.Add(-2 * time.Minute)}))
+	require.NoError(t, store.Set("b", &Entry{StoredAt: now.Add(-time.Minute)}))
+	require.NoError(t, store.Set("c", &Entry{StoredAt: now}))
+
+	_, ok, _ := store.Get("a")
+	assert.False(t, ok)
+	_, ok, _ = store.Get("b")
+	assert.True(t, ok)
+	_, ok, _ = store.Get("c")
+	assert.True(t, ok)
+}
+
+// TestDiskStore tests storing, loading and deleting entries on disk.
+func TestDiskStore(t *testing.T) {
+	store, err := NewDiskStore(t.TempDir())
+	require.NoError(t, err)
+
+	_, ok, err := store.Get("https://api.example.com/resources/1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	entry := &Entry{
+		URL:         "https://api.example.com/resources/1",
+		StatusCode:  http.StatusOK,
+		Header:      http.Header{"Etag": {`"v1"`}},
+		Body:        []byte(`{"id":1}`),
+		StoredAt:    time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		VaryHeaders: map[string]string{"Accept": "application/json"},
+	}
+	require.NoError(t, store.Set(entry.URL, entry))
+
+	loaded, ok, err := store.Get(entry.URL)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, entry, loaded)
+
+	require.NoError(t, store.Delete(entry.URL))
+	require.NoError(t, store.Delete(entry.URL))
+	_, ok, err = store.Get(entry.URL)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
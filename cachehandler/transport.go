@@ -0,0 +1,208 @@
+// cachehandler/transport.go
+/* Package cachehandler provides an optional RFC 9111 style private cache for GET requests. Fresh responses are served
+without contacting the server, stale responses are revalidated with If-None-Match and If-Modified-Since, a 304 Not
+Modified is answered with the stored body, and stale responses can be served for a while when the server fails.
+Responses are kept in a pluggable Store, with in-memory and on-disk implementations provided. */
+package cachehandler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/headers"
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"go.uber.org/zap"
+)
+
+// headersNotUpdated lists the stored headers that a 304 response must not replace, as they describe the stored body.
+var headersNotUpdated = []string{"Content-Length", "Content-Encoding", "Content-Range", "Transfer-Encoding"}
+
+// Transport is an http.RoundTripper that caches GET responses in a Store.
+type Transport struct {
+	Base         http.RoundTripper // Transport used to send requests; http.DefaultTransport when nil
+	Store        Store             // Store holding the cached responses
+	StaleIfError time.Duration     // How long past freshness a response may be served when the server fails
+	Logger       logger.Logger     // Logger for cache hits, revalidations and store failures
+	Identity     func() string     // Identity requests are sent as; responses are cached per identity when set
+	now          func() time.Time
+}
+
+// NewTransport creates a caching Transport sending requests through base.
+func NewTransport(base http.RoundTripper, store Store, staleIfError time.Duration, log logger.Logger) *Transport {
+	return &Transport{
+		Base:         base,
+		Store:        store,
+		StaleIfError: staleIfError,
+		Logger:       log,
+		now:          time.Now,
+	}
+}
+
+// RoundTrip serves req from the cache when possible and stores cacheable responses. Successful requests with other
+// methods invalidate the cached response for their URL and identity.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := t.cacheKey(req)
+	if req.Method != http.MethodGet {
+		resp, err := t.base().RoundTrip(req)
+		if err == nil && !isSafeMethod(req.Method) && resp.StatusCode < 400 {
+			t.delete(key)
+		}
+		return resp, err
+	}
+
+	now := t.now()
+	entry := t.lookup(key, req)
+	if entry != nil && entry.isFresh(req, now) {
+		t.Logger.Debug("Serving response from cache", zap.String("url", entry.URL), zap.Duration("age", entry.age(now)))
+		return entry.response(req, now), nil
+	}
+
+	outReq := req
+	if entry != nil {
+		outReq = req.Clone(req.Context())
+		headers.SetConditionalHeaders(outReq, entry.Header.Get("Last-Modified"), entry.Header.Get("ETag"))
+	}
+
+	resp, err := t.base().RoundTrip(outReq)
+	if err != nil {
+		if entry != nil && entry.isUsableOnError(t.now(), t.StaleIfError) {
+			t.Logger.Warn("Serving stale response from cache after request failure", zap.String("url", entry.URL), zap.Error(err))
+			return entry.response(req, t.now()), nil
+		}
+		return nil, err
+	}
+
+	if entry != nil {
+		switch resp.StatusCode {
+		case http.StatusNotModified:
+			drainAndClose(resp)
+			entry = entry.revalidated(resp, t.now())
+			t.set(key, entry)
+			t.Logger.Debug("Cached response revalidated", zap.String("url", entry.URL))
+			return entry.response(req, t.now()), nil
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			if entry.isUsableOnError(t.now(), t.StaleIfError) {
+				t.Logger.Warn("Serving stale response from cache after server error", zap.String("url", entry.URL), zap.Int("status_code", resp.StatusCode))
+				drainAndClose(resp)
+				return entry.response(req, t.now()), nil
+			}
+		}
+	}
+
+	if !isStorable(req, resp) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.set(key, &Entry{
+		URL:         req.URL.String(),
+		StatusCode:  resp.StatusCode,
+		Header:      resp.Header.Clone(),
+		Body:        body,
+		StoredAt:    t.now(),
+		VaryHeaders: varyHeaders(req, resp),
+	})
+	return resp, nil
+}
+
+// base returns the transport used to send requests.
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// lookup returns the entry stored for req, or nil if there is none or it was selected by other header values.
+// Store failures are logged and treated as a miss so that the cache never fails a request.
+func (t *Transport) lookup(key string, req *http.Request) *Entry {
+	entry, ok, err := t.Store.Get(key)
+	if err != nil {
+		t.Logger.Warn("Failed to read from response cache", zap.String("key", key), zap.Error(err))
+		return nil
+	}
+	if !ok || !entry.matchesVary(req) {
+		return nil
+	}
+	return entry
+}
+
+// set stores entry, logging failures.
+func (t *Transport) set(key string, entry *Entry) {
+	if err := t.Store.Set(key, entry); err != nil {
+		t.Logger.Warn("Failed to write to response cache", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// delete removes the entry stored under key, logging failures.
+func (t *Transport) delete(key string) {
+	if err := t.Store.Delete(key); err != nil {
+		t.Logger.Warn("Failed to invalidate response cache", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// revalidated returns a copy of the entry updated with the headers of a 304 response and with its age restarted.
+// Stored entries are shared by concurrent requests and are therefore never modified.
+func (e *Entry) revalidated(resp *http.Response, now time.Time) *Entry {
+	updated := *e
+	updated.Header = e.Header.Clone()
+	header := resp.Header.Clone()
+	for _, name := range headersNotUpdated {
+		header.Del(name)
+	}
+	for name, values := range header {
+		updated.Header[name] = values
+	}
+	updated.StoredAt = now
+	return &updated
+}
+
+// response builds a response for req from the entry.
+func (e *Entry) response(req *http.Request, now time.Time) *http.Response {
+	header := e.Header.Clone()
+	header.Set("Age", strconv.FormatInt(int64(e.age(now)/time.Second), 10))
+	return &http.Response{
+		Status:        strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// cacheKey returns the key responses to req are stored under. With an Identity, responses are stored per identity so
+// that a response is never served to a client authenticated as someone else.
+func (t *Transport) cacheKey(req *http.Request) string {
+	if t.Identity == nil {
+		return req.URL.String()
+	}
+	return t.Identity() + " " + req.URL.String()
+}
+
+// isSafeMethod reports whether the method does not change server state, and therefore does not invalidate the cache.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// drainAndClose discards the rest of the response body so that the connection can be reused.
+func drainAndClose(resp *http.Response) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
@@ -0,0 +1,282 @@
+// cachehandler/transport_test.go
+package cachehandler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newTestTransport returns a Transport over base with a controllable clock.
+func newTestTransport(base http.RoundTripper, staleIfError time.Duration) (*Transport, *time.Time) {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Warn", mock.Anything, mock.Anything).Maybe()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	transport := NewTransport(base, NewMemoryStore(0), staleIfError, mockLog)
+	transport.now = func() time.Time { return now }
+	return transport, &now
+}
+
+// newTestResponse builds a response to req.
+func newTestResponse(req *http.Request, statusCode int, header http.Header, body string) *http.Response {
+	recorder := httptest.NewRecorder()
+	for name, values := range header {
+		recorder.Header()[name] = values
+	}
+	recorder.WriteHeader(statusCode)
+	recorder.WriteString(body)
+	resp := recorder.Result()
+	resp.Request = req
+	return resp
+}
+
+// get sends a GET request through the transport and returns the status code and body.
+func get(t *testing.T, transport http.RoundTripper) (int, string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "https://api.example.com/resources/1", nil)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+// TestTransportFreshHit tests that fresh responses are served without contacting the server.
+func TestTransportFreshHit(t *testing.T) {
+	calls := 0
+	transport, now := newTestTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return newTestResponse(req, http.StatusOK, http.Header{"Cache-Control": {"private, max-age=60"}}, `{"id":1}`), nil
+	}), 0)
+
+	status, body := get(t, transport)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"id":1}`, body)
+
+	*now = now.Add(30 * time.Second)
+	status, body = get(t, transport)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"id":1}`, body)
+	assert.Equal(t, 1, calls)
+
+	*now = now.Add(time.Minute)
+	get(t, transport)
+	assert.Equal(t, 2, calls)
+}
+
+// TestTransportRevalidation tests that stale responses are revalidated and a 304 returns the stored body.
+func TestTransportRevalidation(t *testing.T) {
+	var conditional []http.Header
+	transport, _ := newTestTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		conditional = append(conditional, req.Header.Clone())
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			return newTestResponse(req, http.StatusNotModified, http.Header{"Etag": {`"v1"`}, "X-Ratelimit-Remaining": {"4999"}}, ""), nil
+		}
+		return newTestResponse(req, http.StatusOK, http.Header{
+			"Etag":          {`"v1"`},
+			"Last-Modified": {"Wed, 01 May 2024 10:00:00 GMT"},
+			"Content-Type":  {"application/json"},
+		}, `{"id":1}`), nil
+	}), 0)
+
+	get(t, transport)
+	status, body := get(t, transport)
+
+	require.Len(t, conditional, 2)
+	assert.Empty(t, conditional[0].Get("If-None-Match"))
+	assert.Equal(t, `"v1"`, conditional[1].Get("If-None-Match"))
+	assert.Equal(t, "Wed, 01 May 2024 10:00:00 GMT", conditional[1].Get("If-Modified-Since"))
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"id":1}`, body)
+
+	entry, ok, err := transport.Store.Get("https://api.example.com/resources/1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "4999", entry.Header.Get("X-Ratelimit-Remaining"))
+	assert.Equal(t, "application/json", entry.Header.Get("Content-Type"))
+}
+
+// TestTransportConcurrentRevalidation tests that concurrent revalidations of the same entry do not modify the entry
+// other requests are serving from.
+func TestTransportConcurrentRevalidation(t *testing.T) {
+	var remaining int32 = 5000
+	transport, _ := newTestTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			n := atomic.AddInt32(&remaining, -1)
+			return newTestResponse(req, http.StatusNotModified, http.Header{"Etag": {`"v1"`}, "X-Ratelimit-Remaining": {strconv.Itoa(int(n))}}, ""), nil
+		}
+		return newTestResponse(req, http.StatusOK, http.Header{"Etag": {`"v1"`}}, `{"id":1}`), nil
+	}), 0)
+	get(t, transport)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "https://api.example.com/resources/1", nil)
+			resp, err := transport.RoundTrip(req)
+			if assert.NoError(t, err) {
+				body, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				assert.Equal(t, `{"id":1}`, string(body))
+				assert.NotEmpty(t, resp.Header.Get("X-Ratelimit-Remaining"))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestTransportIdentity tests that responses are cached per identity.
+func TestTransportIdentity(t *testing.T) {
+	var calls int
+	transport, _ := newTestTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return newTestResponse(req, http.StatusOK, http.Header{"Cache-Control": {"max-age=60"}}, strconv.Itoa(calls)), nil
+	}), 0)
+	identity := "user-a"
+	transport.Identity = func() string { return identity }
+
+	_, body := get(t, transport)
+	assert.Equal(t, "1", body)
+
+	identity = "user-b"
+	_, body = get(t, transport)
+	assert.Equal(t, "2", body, "a response cached for another identity must not be served")
+
+	identity = "user-a"
+	_, body = get(t, transport)
+	assert.Equal(t, "1", body)
+	assert.Equal(t, 2, calls)
+}
+
+// TestTransportStaleIfError tests that stale responses are served within the stale-if-error window.
+func TestTransportStaleIfError(t *testing.T) {
+	var failure error
+	statusCode := http.StatusOK
+	transport, now := newTestTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if failure != nil {
+			return nil, failure
+		}
+		return newTestResponse(req, statusCode, http.Header{"Etag": {`"v1"`}, "Cache-Control": {"max-age=60"}}, `{"id":1}`), nil
+	}), 5*time.Minute)
+
+	get(t, transport)
+
+	*now = now.Add(2 * time.Minute)
+	statusCode = http.StatusServiceUnavailable
+	status, body := get(t, transport)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"id":1}`, body)
+
+	failure = errors.New("connection refused")
+	status, _ = get(t, transport)
+	assert.Equal(t, http.StatusOK, status)
+
+	*now = now.Add(10 * time.Minute)
+	_, err := transport.RoundTrip(httptest.NewRequest(http.MethodGet, "https://api.example.com/resources/1", nil))
+	assert.ErrorIs(t, err, failure)
+
+	failure = nil
+	status, _ = get(t, transport)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
+
+// TestTransportStaleIfErrorDirective tests that the response's stale-if-error directive overrides the configured window.
+func TestTransportStaleIfErrorDirective(t *testing.T) {
+	fail := false
+	transport, now := newTestTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if fail {
+			return nil, errors.New("connection refused")
+		}
+		return newTestResponse(req, http.StatusOK, http.Header{"Cache-Control": {"max-age=60, stale-if-error=600"}}, "ok"), nil
+	}), 0)
+
+	get(t, transport)
+	fail = true
+	*now = now.Add(5 * time.Minute)
+	status, body := get(t, transport)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", body)
+}
+
+// TestTransportNotStored tests that responses which must not or cannot be reused are not stored.
+func TestTransportNotStored(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		header     http.Header
+	}{
+		{"No store", http.StatusOK, http.Header{"Cache-Control": {"no-store"}, "Etag": {`"v1"`}}},
+		{"No validator or freshness", http.StatusOK, http.Header{}},
+		{"Vary star", http.StatusOK, http.Header{"Vary": {"*"}, "Etag": {`"v1"`}}},
+		{"Not found", http.StatusNotFound, http.Header{"Etag": {`"v1"`}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, _ := newTestTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return newTestResponse(req, tt.statusCode, tt.header, "body"), nil
+			}), 0)
+			get(t, transport)
+			_, ok, err := transport.Store.Get("https://api.example.com/resources/1")
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+// TestTransportVary tests that entries are only reused for requests sending the same values for Vary headers.
+func TestTransportVary(t *testing.T) {
+	calls := 0
+	transport, _ := newTestTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return newTestResponse(req, http.StatusOK, http.Header{"Cache-Control": {"max-age=60"}, "Vary": {"Accept"}}, req.Header.Get("Accept")), nil
+	}), 0)
+
+	for _, accept := range []string{"application/json", "application/json", "application/xml"} {
+		req := httptest.NewRequest(http.MethodGet, "https://api.example.com/resources/1", nil)
+		req.Header.Set("Accept", accept)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, accept, string(body))
+	}
+	assert.Equal(t, 2, calls)
+}
+
+// TestTransportInvalidation tests that successful unsafe requests remove the cached response for their URL.
+func TestTransportInvalidation(t *testing.T) {
+	transport, _ := newTestTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return newTestResponse(req, http.StatusOK, http.Header{"Cache-Control": {"max-age=60"}}, "ok"), nil
+	}), 0)
+
+	get(t, transport)
+	_, err := transport.RoundTrip(httptest.NewRequest(http.MethodPut, "https://api.example.com/resources/1", strings.NewReader("{}")))
+	require.NoError(t, err)
+
+	_, ok, err := transport.Store.Get("https://api.example.com/resources/1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,171 @@
+// circuitbreaker/circuitbreaker.go
+/* Package circuitbreaker stops sending requests to a host or endpoint group that keeps failing. A breaker opens after
+a number of consecutive failures, either connection errors or the responses status.IsTransientError classifies, and
+then fails requests fast with an *apierrors.CircuitOpenError for a cool-down period. After the cool-down it lets a
+limited number of probe requests through: a successful probe closes the breaker and a failed one opens it again. */
+package circuitbreaker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
+)
+
+// Default settings used for zero values in Config.
+const (
+	DefaultFailureThreshold    = 5
+	DefaultCoolDown            = 30 * time.Second
+	DefaultHalfOpenMaxRequests = 1
+)
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	StateClosed   State = iota // Requests are sent and failures counted
+	StateOpen                  // Requests fail fast until the cool-down has passed
+	StateHalfOpen              // A limited number of probe requests are sent to test recovery
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Config holds the settings shared by the circuit breakers of a Group.
+type Config struct {
+	FailureThreshold    int           // Consecutive failures that open the breaker
+	CoolDown            time.Duration // How long an open breaker fails requests before letting probes through
+	HalfOpenMaxRequests int           // Probe requests allowed in flight while half-open
+}
+
+// withDefaults returns the config with zero values replaced by the defaults.
+func (c Config) withDefaults() Config {
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = DefaultFailureThreshold
+	}
+	if c.CoolDown <= 0 {
+		c.CoolDown = DefaultCoolDown
+	}
+	if c.HalfOpenMaxRequests <= 0 {
+		c.HalfOpenMaxRequests = DefaultHalfOpenMaxRequests
+	}
+	return c
+}
+
+// Breaker is the circuit breaker of a single host or endpoint group. It is safe for concurrent use.
+type Breaker struct {
+	key      string
+	config   Config
+	now      func() time.Time
+	onChange func(key string, from, to State)
+
+	mu       sync.Mutex
+	state    State
+	failures int       // Consecutive failures while closed
+	openedAt time.Time // Time the breaker last opened
+	inFlight int       // Probe requests in flight while half-open
+}
+
+// newBreaker creates a closed breaker.
+func newBreaker(key string, config Config, now func() time.Time, onChange func(key string, from, to State)) *Breaker {
+	return &Breaker{key: key, config: config, now: now, onChange: onChange}
+}
+
+// State returns the current state of the breaker. An open breaker reports half-open once its cool-down has passed.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateOpen && !b.now().Before(b.openedAt.Add(b.config.CoolDown)) {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// Allow reports whether a request may be sent. It returns an *apierrors.CircuitOpenError while the breaker is open,
+// or while half-open and the probe requests allowed are already in flight. Every allowed request must be followed by
+// a call to Record.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if b.state == StateOpen {
+		reopenAt := b.openedAt.Add(b.config.CoolDown)
+		if now.Before(reopenAt) {
+			return &apierrors.CircuitOpenError{Key: b.key, RetryAfter: reopenAt.Sub(now)}
+		}
+		b.setState(StateHalfOpen)
+	}
+	if b.state == StateHalfOpen {
+		if b.inFlight >= b.config.HalfOpenMaxRequests {
+			return &apierrors.CircuitOpenError{Key: b.key}
+		}
+		b.inFlight++
+	}
+	return nil
+}
+
+// Record records the outcome of a request allowed by Allow.
+func (b *Breaker) Record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateClosed:
+		if success {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.config.FailureThreshold {
+			b.open()
+		}
+	case StateHalfOpen:
+		b.inFlight--
+		if success {
+			b.failures = 0
+			b.setState(StateClosed)
+		} else {
+			b.open()
+		}
+	}
+}
+
+// Release returns a probe slot taken by Allow for a request whose outcome says nothing about the host, such as a
+// request cancelled by the caller.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateHalfOpen && b.inFlight > 0 {
+		b.inFlight--
+	}
+}
+
+// open moves the breaker to the open state. The caller must hold the lock.
+func (b *Breaker) open() {
+	b.openedAt = b.now()
+	b.inFlight = 0
+	b.setState(StateOpen)
+}
+
+// setState changes the state and reports the transition. The caller must hold the lock.
+func (b *Breaker) setState(state State) {
+	if b.state == state {
+		return
+	}
+	from := b.state
+	b.state = state
+	if b.onChange != nil {
+		b.onChange(b.key, from, state)
+	}
+}
@@ -0,0 +1,153 @@
+// circuitbreaker/circuitbreaker_test.go
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
+	"github.com/deploymenttheory/go-api-http-client/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestGroup returns a Group with a controllable clock.
+func newTestGroup(config Config, keyFunc KeyFunc) (*Group, *time.Time) {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Warn", mock.Anything, mock.Anything).Maybe()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	group := NewGroup(config, keyFunc, mockLog)
+	group.now = func() time.Time { return now }
+	return group, &now
+}
+
+// TestBreakerStates tests opening after consecutive failures, failing fast during the cool-down and closing after a
+// successful probe.
+func TestBreakerStates(t *testing.T) {
+	group, now := newTestGroup(Config{FailureThreshold: 3, CoolDown: time.Minute}, nil)
+	breaker := group.Breaker(httptest.NewRequest(http.MethodGet, "https://acme.jamfcloud.com/api/v1/computers", nil))
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, breaker.Allow())
+		breaker.Record(false)
+	}
+	require.NoError(t, breaker.Allow())
+	breaker.Record(true)
+	assert.Equal(t, StateClosed, breaker.State(), "a success resets the consecutive failures")
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, breaker.Allow())
+		breaker.Record(false)
+	}
+	assert.Equal(t, StateOpen, breaker.State())
+
+	*now = now.Add(20 * time.Second)
+	err := breaker.Allow()
+	assert.ErrorIs(t, err, apierrors.ErrCircuitOpen)
+	var openErr *apierrors.CircuitOpenError
+	require.ErrorAs(t, err, &openErr)
+	assert.Equal(t, "acme.jamfcloud.com", openErr.Key)
+	assert.Equal(t, 40*time.Second, openErr.RetryAfter)
+
+	*now = now.Add(40 * time.Second)
+	assert.Equal(t, StateHalfOpen, breaker.State())
+	require.NoError(t, breaker.Allow())
+	assert.ErrorIs(t, breaker.Allow(), apierrors.ErrCircuitOpen, "only one probe is allowed in flight")
+	breaker.Record(true)
+	assert.Equal(t, StateClosed, breaker.State())
+	assert.NoError(t, breaker.Allow())
+}
+
+// TestBreakerProbeFailure tests that a failed probe opens the breaker for another cool-down.
+func TestBreakerProbeFailure(t *testing.T) {
+	group, now := newTestGroup(Config{FailureThreshold: 1, CoolDown: time.Minute, HalfOpenMaxRequests: 2}, nil)
+	breaker := group.Breaker(httptest.NewRequest(http.MethodGet, "https://api.github.com/repos", nil))
+
+	require.NoError(t, breaker.Allow())
+	breaker.Record(false)
+
+	*now = now.Add(time.Minute)
+	require.NoError(t, breaker.Allow())
+	require.NoError(t, breaker.Allow())
+	breaker.Record(false)
+	assert.Equal(t, StateOpen, breaker.State())
+	assert.ErrorIs(t, breaker.Allow(), apierrors.ErrCircuitOpen)
+
+	*now = now.Add(time.Minute)
+	assert.NoError(t, breaker.Allow())
+}
+
+// TestKeyFuncs tests the built-in circuit breaker keys.
+func TestKeyFuncs(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://acme.jamfcloud.com/api/v1/computers-inventory/123?section=GENERAL", nil)
+	assert.Equal(t, "acme.jamfcloud.com", HostKey(req))
+	assert.Equal(t, "acme.jamfcloud.com/api/v1/computers-inventory/{id}", EndpointGroupKey(req))
+
+	req = httptest.NewRequest(http.MethodGet, "https://graph.microsoft.com/v1.0/groups/0b2b9ac3-2a37-4e3a-9f8a-3c1a4e1f7f6d/members", nil)
+	assert.Equal(t, "graph.microsoft.com/v1.0/groups/{id}/members", EndpointGroupKey(req))
+}
+
+// TestTransport tests which outcomes the transport counts as failures and that open breakers fail fast.
+func TestTransport(t *testing.T) {
+	statusCode := http.StatusServiceUnavailable
+	var transportErr error
+	calls := 0
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if transportErr != nil {
+			return nil, transportErr
+		}
+		return &http.Response{StatusCode: statusCode, Body: http.NoBody, Request: req}, nil
+	})
+	group, _ := newTestGroup(Config{FailureThreshold: 2, CoolDown: time.Minute}, nil)
+	transport := NewTransport(base, group)
+	newRequest := func() *http.Request {
+		return httptest.NewRequest(http.MethodGet, "https://acme.jamfcloud.com/api/v1/computers", nil)
+	}
+
+	// Client errors are not failures of the host
+	statusCode = http.StatusNotFound
+	for i := 0; i < 3; i++ {
+		_, err := transport.RoundTrip(newRequest())
+		require.NoError(t, err)
+	}
+
+	// Cancelled requests are not counted
+	transportErr = context.Canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 3; i++ {
+		_, err := transport.RoundTrip(newRequest().WithContext(ctx))
+		require.Error(t, err)
+	}
+	assert.Equal(t, StateClosed, group.Breaker(newRequest()).State())
+
+	// Connection errors and transient responses are
+	transportErr = errors.New("connection refused")
+	_, err := transport.RoundTrip(newRequest())
+	require.Error(t, err)
+	transportErr = nil
+	statusCode = http.StatusServiceUnavailable
+	_, err = transport.RoundTrip(newRequest())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]State{"acme.jamfcloud.com": StateOpen}, group.States())
+
+	calls = 0
+	_, err = transport.RoundTrip(newRequest())
+	assert.ErrorIs(t, err, apierrors.ErrCircuitOpen)
+	assert.Equal(t, 0, calls)
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,104 @@
+// circuitbreaker/group.go
+package circuitbreaker
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"go.uber.org/zap"
+)
+
+// KeyFunc returns the key of the circuit breaker responsible for a request.
+type KeyFunc func(req *http.Request) string
+
+// identifierSegment matches path segments that identify a single resource: numbers and UUIDs.
+var identifierSegment = regexp.MustCompile(`^(\d+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`)
+
+// HostKey keys circuit breakers by host, so that an outage of one instance does not affect requests to others.
+func HostKey(req *http.Request) string {
+	return req.URL.Host
+}
+
+// EndpointGroupKey keys circuit breakers by host and path, with resource identifiers replaced by {id}, so that a
+// failing endpoint such as /api/v1/computers-inventory/{id} does not stop requests to the rest of the API.
+func EndpointGroupKey(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for i, segment := range segments {
+		if identifierSegment.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return req.URL.Host + "/" + strings.Join(segments, "/")
+}
+
+// Group holds the circuit breakers of a client, one per key.
+type Group struct {
+	config  Config
+	keyFunc KeyFunc
+	log     logger.Logger
+	now     func() time.Time
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+// NewGroup creates a Group of circuit breakers keyed by keyFunc, or by HostKey if keyFunc is nil.
+func NewGroup(config Config, keyFunc KeyFunc, log logger.Logger) *Group {
+	if keyFunc == nil {
+		keyFunc = HostKey
+	}
+	return &Group{
+		config:   config.withDefaults(),
+		keyFunc:  keyFunc,
+		log:      log,
+		now:      time.Now,
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+// Breaker returns the circuit breaker responsible for req, creating it if needed.
+func (g *Group) Breaker(req *http.Request) *Breaker {
+	key := g.keyFunc(req)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	breaker, ok := g.breakers[key]
+	if !ok {
+		breaker = newBreaker(key, g.config, g.now, g.logStateChange)
+		g.breakers[key] = breaker
+	}
+	return breaker
+}
+
+// States returns the state of every circuit breaker by key.
+func (g *Group) States() map[string]State {
+	g.mu.Lock()
+	breakers := make(map[string]*Breaker, len(g.breakers))
+	for key, breaker := range g.breakers {
+		breakers[key] = breaker
+	}
+	g.mu.Unlock()
+
+	states := make(map[string]State, len(breakers))
+	for key, breaker := range breakers {
+		states[key] = breaker.State()
+	}
+	return states
+}
+
+// logStateChange logs circuit breaker transitions.
+func (g *Group) logStateChange(key string, from, to State) {
+	if g.log == nil {
+		return
+	}
+	fields := []zap.Field{zap.String("key", key), zap.String("from", from.String()), zap.String("to", to.String())}
+	if to == StateOpen {
+		g.log.Warn("Circuit breaker opened, failing requests fast", append(fields, zap.Duration("cool_down", g.config.CoolDown))...)
+		return
+	}
+	g.log.Info("Circuit breaker state changed", fields...)
+}
@@ -0,0 +1,44 @@
+// circuitbreaker/transport.go
+package circuitbreaker
+
+import (
+	"net/http"
+
+	"github.com/deploymenttheory/go-api-http-client/status"
+)
+
+// Transport is an http.RoundTripper that sends requests through the circuit breakers of a Group.
+type Transport struct {
+	Base  http.RoundTripper // Transport used to send requests; http.DefaultTransport when nil
+	Group *Group            // Circuit breakers the requests are sent through
+}
+
+// NewTransport creates a Transport sending requests through base when their circuit breaker allows it.
+func NewTransport(base http.RoundTripper, group *Group) *Transport {
+	return &Transport{Base: base, Group: group}
+}
+
+// RoundTrip sends req unless its circuit breaker is open, and records the outcome. Connection errors and transient
+// error responses count as failures, any other response as a success. Requests cancelled by the caller are not
+// counted.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	breaker := t.Group.Breaker(req)
+	if err := breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		breaker.Release()
+	case err != nil:
+		breaker.Record(false)
+	default:
+		breaker.Record(!status.IsTransientError(resp))
+	}
+	return resp, err
+}
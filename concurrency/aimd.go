@@ -0,0 +1,61 @@
+// concurrency/aimd.go
+package concurrency
+
+import (
+	"math"
+	"time"
+)
+
+// LimitAlgorithm calculates the next concurrency limit from the outcome of a request.
+type LimitAlgorithm interface {
+	// Update returns the new limit given the current limit, the units in use when the request completed, its
+	// latency and whether it showed signs of overload such as rate limiting or server errors.
+	Update(limit, inUse int, latency time.Duration, overloaded bool) int
+}
+
+// DefaultBackoffRatio is the factor the AIMD limit is multiplied by on overload.
+const DefaultBackoffRatio = 0.9
+
+// AIMD adjusts the limit by additive increase and multiplicative decrease, as TCP congestion control does. The limit
+// grows by one after each request that completes without overload while at least half of the limit is in use, and
+// is multiplied by BackoffRatio when a request shows overload or takes longer than LatencyThreshold.
+type AIMD struct {
+	MinLimit         int           // Lowest limit; MinConcurrency when zero
+	MaxLimit         int           // Highest limit; MaxConcurrency when zero
+	BackoffRatio     float64       // Factor applied on overload, between 0 and 1; DefaultBackoffRatio when zero
+	LatencyThreshold time.Duration // Latency treated as overload; ResponseTimeCriticalThreshold when zero
+}
+
+// Update returns the new limit.
+func (a AIMD) Update(limit, inUse int, latency time.Duration, overloaded bool) int {
+	minLimit, maxLimit := a.MinLimit, a.MaxLimit
+	if minLimit <= 0 {
+		minLimit = MinConcurrency
+	}
+	if maxLimit <= 0 {
+		maxLimit = MaxConcurrency
+	}
+	ratio := a.BackoffRatio
+	if ratio <= 0 || ratio >= 1 {
+		ratio = DefaultBackoffRatio
+	}
+	threshold := a.LatencyThreshold
+	if threshold <= 0 {
+		threshold = ResponseTimeCriticalThreshold
+	}
+
+	switch {
+	case overloaded || latency > threshold:
+		limit = int(math.Floor(float64(limit) * ratio))
+	case inUse*2 >= limit:
+		limit++
+	}
+
+	if limit < minLimit {
+		return minLimit
+	}
+	if limit > maxLimit {
+		return maxLimit
+	}
+	return limit
+}
@@ -4,7 +4,8 @@ package concurrency
 import "time"
 
 const (
-	// Concurrency constants define parameters related to managing concurrent requests.
+	// Concurrency constants define parameters related to managing concurrent requests. Those used to adjust
+	// the concurrency limit are the defaults of Tuning and can be changed per ConcurrencyHandler.
 
 	// MaxConcurrency represents the maximum number of concurrent requests the system is designed to handle safely.
 	MaxConcurrency = 10
@@ -13,6 +14,10 @@ const (
 	// even under low traffic conditions or when scaling down due to low resource utilization.
 	MinConcurrency = 1
 
+	// DefaultPermitTimeout is the longest a request waits for a concurrency permit before failing with
+	// apierrors.ErrPermitTimeout.
+	DefaultPermitTimeout = 10 * time.Second
+
 	// EvaluationInterval specifies the frequency at which the system evaluates its performance metrics
 	// to make decisions about scaling concurrency up or down.
 	EvaluationInterval = 1 * time.Minute
@@ -57,6 +62,7 @@ const (
 
 	debounceScaleDownThreshold = 5 // Number of consecutive triggers before scaling down
 
-	//
+	// AcceptableAverageResponseTime is the average response time above which unstable response times
+	// count towards scaling down.
 	AcceptableAverageResponseTime = 100 * time.Millisecond
 )
@@ -6,11 +6,15 @@ import (
 	"time"
 
 	"github.com/deploymenttheory/go-api-http-client/logger"
+	"github.com/google/uuid"
 )
 
 // ConcurrencyHandler controls the number of concurrent HTTP requests.
 type ConcurrencyHandler struct {
-	sem                      chan struct{}
+	limiter                  *Limiter
+	permits                  map[uuid.UUID]int // Weight of each permit held, by request ID
+	Algorithm                LimitAlgorithm    // Adjusts the limit from the outcome of each request
+	tuning                   Tuning            // Permit timeout and thresholds, with defaults applied
 	logger                   logger.Logger
 	AcquisitionTimes         []time.Duration
 	lock                     sync.Mutex
@@ -53,16 +57,40 @@ type ConcurrencyMetrics struct {
 // NewConcurrencyHandler initializes a new ConcurrencyHandler with the given
 // concurrency limit, logger, and concurrency metrics. The ConcurrencyHandler ensures
 // no more than a certain number of concurrent requests are made.
-// It uses a semaphore to control concurrency.
+// It uses a resizable weighted semaphore to control concurrency, whose limit is adjusted between MinConcurrency and
+// the larger of limit and MaxConcurrency by AIMD.
 func NewConcurrencyHandler(limit int, logger logger.Logger, metrics *ConcurrencyMetrics) *ConcurrencyHandler {
+	return NewConcurrencyHandlerWithTuning(limit, logger, metrics, Tuning{})
+}
+
+// NewConcurrencyHandlerWithTuning initializes a new ConcurrencyHandler as NewConcurrencyHandler does, waiting for
+// permits and adjusting the limit by tuning. Zero fields of tuning take their value from DefaultTuning. The response
+// time standard deviation threshold of metrics is set from the tuning unless it is already set.
+func NewConcurrencyHandlerWithTuning(limit int, logger logger.Logger, metrics *ConcurrencyMetrics, tuning Tuning) *ConcurrencyHandler {
+	tuning = tuning.WithDefaults(DefaultTuning())
+	if metrics != nil && metrics.ResponseTimeVariability.StdDevThreshold == 0 {
+		metrics.ResponseTimeVariability.StdDevThreshold = tuning.ResponseTimeStdDevThreshold.Seconds()
+	}
 	return &ConcurrencyHandler{
-		sem:              make(chan struct{}, limit),
+		limiter: NewLimiter(limit),
+		permits: make(map[uuid.UUID]int),
+		Algorithm: AIMD{
+			MinLimit:         tuning.MinConcurrency,
+			MaxLimit:         max(limit, tuning.MaxConcurrency),
+			LatencyThreshold: tuning.ResponseTimeCriticalThreshold,
+		},
+		tuning:           tuning,
 		logger:           logger,
 		AcquisitionTimes: []time.Duration{},
 		Metrics:          metrics,
 	}
 }
 
+// Tuning returns the permit timeout and thresholds the handler uses, with defaults applied.
+func (ch *ConcurrencyHandler) Tuning() Tuning {
+	return ch.tuning
+}
+
 // RequestIDKey is type used as a key for storing and retrieving
 // request-specific identifiers from a context.Context object. This private
 // type ensures that the key is distinct and prevents accidental value
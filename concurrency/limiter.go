@@ -0,0 +1,307 @@
+// concurrency/limiter.go
+package concurrency
+
+import (
+	"container/list"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
+)
+
+// Priority orders requests waiting for a permit. Higher priorities are served first.
+type Priority int
+
+// Request priorities. The zero value is PriorityNormal.
+const (
+	PriorityBackground  Priority = -1 // Bulk work such as exports and syncs
+	PriorityNormal      Priority = 0  // Default priority
+	PriorityInteractive Priority = 1  // Lookups a user is waiting on
+)
+
+// String returns the name of the priority.
+func (p Priority) String() string {
+	switch p {
+	case PriorityBackground:
+		return "background"
+	case PriorityNormal:
+		return "normal"
+	case PriorityInteractive:
+		return "interactive"
+	default:
+		return "custom"
+	}
+}
+
+// PermitRequest describes the permit a request waits for.
+type PermitRequest struct {
+	Weight   int      // Units the permit counts as against the limit; at least 1
+	Priority Priority // Waiting requests are served highest priority first
+	Caller   string   // Tenant or caller; waiting requests of the same priority are served round robin across callers
+}
+
+// Limiter is a weighted semaphore whose limit can change while permits are held. Waiting requests are served
+// highest priority first, round robin across callers within a priority and in arrival order for each caller.
+// The request to be served next blocks the ones behind it until it fits, so heavy permits are not starved by light
+// ones. A permit heavier than the limit is granted once no other permit is held. With a maximum queue depth, a
+// request arriving at a full queue displaces the newest waiter of a lower priority, or is shed itself, with an
+// *apierrors.OverloadError. It is safe for concurrent use.
+type Limiter struct {
+	mu       sync.Mutex
+	limit    int
+	inUse    int
+	maxQueue int          // Maximum number of waiting requests, zero for no maximum
+	waiting  int          // Number of waiting requests
+	queues   []*waitQueue // Queues by priority, highest first
+}
+
+// limiterWaiter is a goroutine waiting for a permit.
+type limiterWaiter struct {
+	PermitRequest
+	element *list.Element
+	ready   chan struct{} // Closed once the permit is granted or the waiter is shed
+	err     error         // Set when the waiter is shed
+}
+
+// waitQueue holds the requests of one priority waiting for a permit.
+type waitQueue struct {
+	priority Priority
+	callers  map[string]*list.List // Waiters of each caller, oldest first
+	order    []string              // Callers with waiters, served round robin
+	next     int                   // Index in order of the caller served next
+}
+
+// NewLimiter creates a Limiter allowing limit units in use at once. The limit is at least one.
+func NewLimiter(limit int) *Limiter {
+	if limit < 1 {
+		limit = 1
+	}
+	return &Limiter{limit: limit}
+}
+
+// Acquire blocks until a permit of the given weight and normal priority is granted or ctx is done.
+func (l *Limiter) Acquire(ctx context.Context, weight int) error {
+	return l.AcquirePermit(ctx, PermitRequest{Weight: weight})
+}
+
+// AcquirePermit blocks until the requested permit is granted or ctx is done. It fails straight away with an
+// *apierrors.OverloadError if the queue is full of requests of the same or a higher priority.
+func (l *Limiter) AcquirePermit(ctx context.Context, request PermitRequest) error {
+	if request.Weight < 1 {
+		request.Weight = 1
+	}
+
+	l.mu.Lock()
+	if l.waiting == 0 && l.fits(request.Weight) {
+		l.inUse += request.Weight
+		l.mu.Unlock()
+		return nil
+	}
+	if l.maxQueue > 0 && l.waiting >= l.maxQueue && !l.shedBelow(request.Priority) {
+		l.mu.Unlock()
+		return &apierrors.OverloadError{QueueDepth: l.maxQueue, Priority: request.Priority.String()}
+	}
+	waiter := &limiterWaiter{PermitRequest: request, ready: make(chan struct{})}
+	l.queue(request.Priority).push(waiter)
+	l.waiting++
+	l.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return waiter.err
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		select {
+		case <-waiter.ready:
+			if waiter.err != nil {
+				return waiter.err
+			}
+			// Granted while being canceled; give the permit back
+			l.inUse -= request.Weight
+		default:
+			l.queue(request.Priority).remove(waiter, false)
+			l.waiting--
+		}
+		l.notify()
+		return ctx.Err()
+	}
+}
+
+// TryAcquire acquires a permit of the given weight only if it can be granted straight away.
+func (l *Limiter) TryAcquire(weight int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.waiting == 0 && l.fits(weight) {
+		l.inUse += weight
+		return true
+	}
+	return false
+}
+
+// Release returns a permit of the given weight.
+func (l *Limiter) Release(weight int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inUse -= weight
+	if l.inUse < 0 {
+		l.inUse = 0
+	}
+	l.notify()
+}
+
+// SetLimit changes the limit, at least one. Permits already granted are kept when the limit shrinks below them;
+// new permits wait until enough have been released.
+func (l *Limiter) SetLimit(limit int) {
+	if limit < 1 {
+		limit = 1
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = limit
+	l.notify()
+}
+
+// SetMaxQueueDepth limits the number of requests waiting for a permit. Zero or less removes the limit. Requests
+// already waiting are kept when the depth shrinks below them.
+func (l *Limiter) SetMaxQueueDepth(depth int) {
+	if depth < 0 {
+		depth = 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maxQueue = depth
+}
+
+// Limit returns the current limit.
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+// InUse returns the units currently held.
+func (l *Limiter) InUse() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inUse
+}
+
+// Waiting returns the number of requests waiting for a permit.
+func (l *Limiter) Waiting() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.waiting
+}
+
+// fits reports whether a permit of weight can be granted. The caller must hold the lock.
+func (l *Limiter) fits(weight int) bool {
+	return l.inUse+weight <= l.limit || l.inUse == 0
+}
+
+// notify grants permits to the waiters served next for as long as they fit. The caller must hold the lock.
+func (l *Limiter) notify() {
+	for _, queue := range l.queues {
+		for waiter := queue.front(); waiter != nil; waiter = queue.front() {
+			if !l.fits(waiter.Weight) {
+				return
+			}
+			l.inUse += waiter.Weight
+			queue.remove(waiter, true)
+			l.waiting--
+			close(waiter.ready)
+		}
+	}
+}
+
+// shedBelow sheds the newest waiter of the lowest priority below priority, taken from the caller with the most
+// waiters, reporting whether one was shed. The caller must hold the lock.
+func (l *Limiter) shedBelow(priority Priority) bool {
+	for i := len(l.queues) - 1; i >= 0; i-- {
+		queue := l.queues[i]
+		if queue.priority >= priority {
+			return false
+		}
+		if waiter := queue.newest(); waiter != nil {
+			queue.remove(waiter, false)
+			l.waiting--
+			waiter.err = &apierrors.OverloadError{QueueDepth: l.maxQueue, Priority: waiter.Priority.String()}
+			close(waiter.ready)
+			return true
+		}
+	}
+	return false
+}
+
+// queue returns the wait queue of priority, creating it if needed. The caller must hold the lock.
+func (l *Limiter) queue(priority Priority) *waitQueue {
+	i := sort.Search(len(l.queues), func(i int) bool { return l.queues[i].priority <= priority })
+	if i < len(l.queues) && l.queues[i].priority == priority {
+		return l.queues[i]
+	}
+	queue := &waitQueue{priority: priority, callers: make(map[string]*list.List)}
+	l.queues = append(l.queues, nil)
+	copy(l.queues[i+1:], l.queues[i:])
+	l.queues[i] = queue
+	return queue
+}
+
+// push adds a waiter behind the other waiters of its caller.
+func (q *waitQueue) push(waiter *limiterWaiter) {
+	waiters, ok := q.callers[waiter.Caller]
+	if !ok {
+		waiters = list.New()
+		q.callers[waiter.Caller] = waiters
+		q.order = append(q.order, waiter.Caller)
+	}
+	waiter.element = waiters.PushBack(waiter)
+}
+
+// front returns the waiter served next, or nil if the queue is empty.
+func (q *waitQueue) front() *limiterWaiter {
+	if len(q.order) == 0 {
+		return nil
+	}
+	return q.callers[q.order[q.next]].Front().Value.(*limiterWaiter)
+}
+
+// newest returns the last waiter of the caller with the most waiters, or nil if the queue is empty.
+func (q *waitQueue) newest() *limiterWaiter {
+	var longest *list.List
+	for _, caller := range q.order {
+		if waiters := q.callers[caller]; longest == nil || waiters.Len() > longest.Len() {
+			longest = waiters
+		}
+	}
+	if longest == nil {
+		return nil
+	}
+	return longest.Back().Value.(*limiterWaiter)
+}
+
+// remove takes a waiter out of the queue. Serving a waiter moves the round robin on to the next caller.
+func (q *waitQueue) remove(waiter *limiterWaiter, served bool) {
+	waiters := q.callers[waiter.Caller]
+	waiters.Remove(waiter.element)
+
+	index := 0
+	for index < len(q.order) && q.order[index] != waiter.Caller {
+		index++
+	}
+	if waiters.Len() > 0 {
+		if served {
+			q.next = (index + 1) % len(q.order)
+		}
+		return
+	}
+
+	delete(q.callers, waiter.Caller)
+	q.order = append(q.order[:index], q.order[index+1:]...)
+	if index < q.next {
+		q.next--
+	}
+	if q.next >= len(q.order) {
+		q.next = 0
+	}
+}
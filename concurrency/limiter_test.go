@@ -0,0 +1,165 @@
+// concurrency/limiter_test.go
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLimiterResizeUnderLoad tests that the limit holds while it changes under concurrent acquire and release.
+func TestLimiterResizeUnderLoad(t *testing.T) {
+	limiter := NewLimiter(4)
+	var current, peak int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			require.NoError(t, limiter.Acquire(context.Background(), 1))
+			n := atomic.AddInt32(&current, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&current, -1)
+			limiter.Release(1)
+			if i%10 == 0 {
+				limiter.SetLimit(2 + i%3)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(4))
+	assert.Equal(t, 0, limiter.InUse())
+}
+
+// TestLimiterWeightedPermits tests that heavy permits are served in order and granted alone when above the limit.
+func TestLimiterWeightedPermits(t *testing.T) {
+	limiter := NewLimiter(3)
+	require.NoError(t, limiter.Acquire(context.Background(), 2))
+	assert.False(t, limiter.TryAcquire(2))
+
+	heavy := make(chan struct{})
+	go func() {
+		require.NoError(t, limiter.Acquire(context.Background(), 5))
+		close(heavy)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	// A light permit that would fit still queues behind the heavy one
+	assert.False(t, limiter.TryAcquire(1))
+
+	limiter.Release(2)
+	select {
+	case <-heavy:
+	case <-time.After(time.Second):
+		t.Fatal("heavy permit was not granted once the limiter was idle")
+	}
+	assert.Equal(t, 5, limiter.InUse())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Acquire(ctx, 1), context.DeadlineExceeded)
+
+	limiter.Release(5)
+	assert.True(t, limiter.TryAcquire(3))
+}
+
+// TestAIMD tests additive increase while busy and multiplicative decrease on overload or slow responses.
+func TestAIMD(t *testing.T) {
+	aimd := AIMD{MinLimit: 2, MaxLimit: 20, BackoffRatio: 0.5, LatencyThreshold: time.Second}
+
+	assert.Equal(t, 11, aimd.Update(10, 5, 100*time.Millisecond, false))
+	assert.Equal(t, 10, aimd.Update(10, 4, 100*time.Millisecond, false), "no increase while mostly idle")
+	assert.Equal(t, 20, aimd.Update(20, 20, 100*time.Millisecond, false))
+	assert.Equal(t, 5, aimd.Update(10, 10, 100*time.Millisecond, true))
+	assert.Equal(t, 5, aimd.Update(10, 10, 2*time.Second, false))
+	assert.Equal(t, 2, aimd.Update(3, 3, 0, true))
+}
+
+// TestLimiterPriorityAndFairness tests that waiting requests are served by priority, then round robin across callers.
+func TestLimiterPriorityAndFairness(t *testing.T) {
+	limiter := NewLimiter(1)
+	require.True(t, limiter.TryAcquire(1))
+
+	served := make(chan string, 5)
+	waiting := 0
+	for _, waiter := range []struct {
+		name    string
+		request PermitRequest
+	}{
+		{"export-1", PermitRequest{Priority: PriorityBackground, Caller: "export"}},
+		{"tenant-a-1", PermitRequest{Caller: "tenant-a"}},
+		{"tenant-a-2", PermitRequest{Caller: "tenant-a"}},
+		{"tenant-b-1", PermitRequest{Caller: "tenant-b"}},
+		{"lookup-1", PermitRequest{Priority: PriorityInteractive, Caller: "ui"}},
+	} {
+		go func(name string, request PermitRequest) {
+			assert.NoError(t, limiter.AcquirePermit(context.Background(), request))
+			served <- name
+		}(waiter.name, waiter.request)
+		// Wait until queued so that arrival order is deterministic
+		waiting++
+		for limiter.Waiting() < waiting {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	var order []string
+	for i := 0; i < 5; i++ {
+		limiter.Release(1)
+		select {
+		case name := <-served:
+			order = append(order, name)
+		case <-time.After(time.Second):
+			t.Fatalf("no waiter served after %v", order)
+		}
+	}
+	assert.Equal(t, []string{"lookup-1", "tenant-a-1", "tenant-b-1", "tenant-a-2", "export-1"}, order)
+}
+
+// TestLimiterMaxQueueDepth tests that a full queue sheds arriving requests unless they displace a lower priority.
+func TestLimiterMaxQueueDepth(t *testing.T) {
+	limiter := NewLimiter(1)
+	limiter.SetMaxQueueDepth(1)
+	require.True(t, limiter.TryAcquire(1))
+
+	background := make(chan error, 1)
+	go func() {
+		background <- limiter.AcquirePermit(context.Background(), PermitRequest{Priority: PriorityBackground})
+	}()
+	for limiter.Waiting() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	err := limiter.AcquirePermit(context.Background(), PermitRequest{Priority: PriorityBackground})
+	var overload *apierrors.OverloadError
+	require.ErrorAs(t, err, &overload)
+	assert.ErrorIs(t, err, apierrors.ErrOverloaded)
+	assert.Equal(t, "background", overload.Priority)
+
+	interactive := make(chan error, 1)
+	go func() {
+		interactive <- limiter.AcquirePermit(context.Background(), PermitRequest{Priority: PriorityInteractive})
+	}()
+	select {
+	case err := <-background:
+		assert.ErrorIs(t, err, apierrors.ErrOverloaded, "the background waiter is displaced")
+	case <-time.After(time.Second):
+		t.Fatal("background waiter was not shed")
+	}
+
+	limiter.Release(1)
+	assert.NoError(t, <-interactive)
+}
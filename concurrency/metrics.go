@@ -43,7 +43,10 @@ var metricWeights = map[string]float64{
 // The method should be called after each significant interaction with the external system (e.g., an HTTP request) to
 // ensure concurrency levels are adapted to current conditions.
 //
-// Returns: None. The function directly calls the ScaleUp or ScaleDown methods as needed.
+// The scaling decisions are applied by the handler's limit algorithm, AIMD by default, which also treats a response
+// time above its latency threshold as overload.
+//
+// Returns: None. The function adjusts the concurrency limit directly.
 //
 // Note: This function does not return any value; it performs actions based on internal assessments and logs outcomes.
 func (ch *ConcurrencyHandler) EvaluateAndAdjustConcurrency(resp *http.Response, responseTime time.Duration) {
@@ -81,48 +84,43 @@ func (ch *ConcurrencyHandler) EvaluateAndAdjustConcurrency(resp *http.Response,
 	}
 
 	// Check critical thresholds
-	if rateLimitFeedback <= RateLimitCriticalThreshold || responseCodeFeedback < 0 {
-		if weightedRateLimitScore >= ErrorResponseThreshold || weightedResponseCodeScore >= ErrorResponseThreshold {
+	if rateLimitFeedback <= ch.tuning.RateLimitCriticalThreshold || responseCodeFeedback < 0 {
+		if weightedRateLimitScore >= ch.tuning.ErrorResponseThreshold || weightedResponseCodeScore >= ch.tuning.ErrorResponseThreshold {
 			ch.logger.Warn("Scaling down due to critical threshold breach",
 				zap.String("event", "CriticalThresholdBreach"),
 				zap.Int("rateLimitFeedback", rateLimitFeedback),
 				zap.Float64("errorResponseRate", weightedResponseCodeScore),
 			)
-			ch.ScaleDown()
+			ch.adjustLimit(responseTime, true)
 			return
 		}
 	}
 
 	// Evaluate cumulative impact and make a scaling decision based on the cumulative score and other metrics.
+	limitBefore, utilized := ch.limiter.Limit(), ch.limiter.InUse()
 	if cumulativeScore < 0 {
-		utilizedBefore := len(ch.sem) // Tokens in use before scaling down.
-		ch.ScaleDown()
-		utilizedAfter := len(ch.sem) // Tokens in use after scaling down.
+		ch.adjustLimit(responseTime, true)
 		ch.logger.Info("Concurrency scaling decision: scale down.",
 			zap.Float64("cumulativeScore", cumulativeScore),
-			zap.Int("utilizedTokensBefore", utilizedBefore),
-			zap.Int("utilizedTokensAfter", utilizedAfter),
-			zap.Int("availableTokensBefore", cap(ch.sem)-utilizedBefore),
-			zap.Int("availableTokensAfter", cap(ch.sem)-utilizedAfter),
+			zap.Int("utilizedTokens", utilized),
+			zap.Int("limitBefore", limitBefore),
+			zap.Int("limitAfter", ch.limiter.Limit()),
 			zap.String("reason", "Cumulative impact of metrics suggested an overload."),
 		)
 	} else if cumulativeScore > 0 {
-		utilizedBefore := len(ch.sem) // Tokens in use before scaling up.
-		ch.ScaleUp()
-		utilizedAfter := len(ch.sem) // Tokens in use after scaling up.
+		ch.adjustLimit(responseTime, false)
 		ch.logger.Info("Concurrency scaling decision: scale up.",
 			zap.Float64("cumulativeScore", cumulativeScore),
-			zap.Int("utilizedTokensBefore", utilizedBefore),
-			zap.Int("utilizedTokensAfter", utilizedAfter),
-			zap.Int("availableTokensBefore", cap(ch.sem)-utilizedBefore),
-			zap.Int("availableTokensAfter", cap(ch.sem)-utilizedAfter),
+			zap.Int("utilizedTokens", utilized),
+			zap.Int("limitBefore", limitBefore),
+			zap.Int("limitAfter", ch.limiter.Limit()),
 			zap.String("reason", "Metrics indicate available resources to handle more load."),
 		)
 	} else {
 		ch.logger.Info("Concurrency scaling decision: no change.",
 			zap.Float64("cumulativeScore", cumulativeScore),
-			zap.Int("currentUtilizedTokens", len(ch.sem)),
-			zap.Int("currentAvailableTokens", cap(ch.sem)-len(ch.sem)),
+			zap.Int("currentUtilizedTokens", utilized),
+			zap.Int("currentAvailableTokens", limitBefore-utilized),
 			zap.String("reason", "Metrics are stable, maintaining current concurrency level."),
 		)
 	}
@@ -187,7 +185,7 @@ func (ch *ConcurrencyHandler) MonitorServerResponseCodes(resp *http.Response) in
 	)
 
 	// Only suggest a scale-down if the error rate exceeds the threshold
-	if errorRate > ErrorRateThreshold {
+	if errorRate > ch.tuning.ErrorRateThreshold {
 		return -1 // Suggest decrease concurrency
 	}
 	return 0 // Default to no change if error rate is within acceptable limits
@@ -202,7 +200,7 @@ var responseTimesLock sync.Mutex
 // The function first appends the latest response time to a sliding window of the last 10 response times to maintain a recent history. It then calculates the standard deviation and the average of these times. The standard deviation helps determine the variability or consistency of response times, while the average gives a central tendency.
 //
 // Based on these calculated metrics, the function employs a multi-factor decision mechanism:
-// - If the standard deviation exceeds a pre-defined threshold and the average response time is greater than an acceptable maximum, a debounce counter is incremented. This counter must reach a predefined threshold (debounceScaleDownThreshold) before a decision to decrease concurrency is made, ensuring that only sustained negative trends lead to a scale down.
+// - If the standard deviation exceeds a pre-defined threshold and the average response time is greater than an acceptable maximum, a debounce counter is incremented. This counter must reach the DebounceScaleDownThreshold of the handler's Tuning before a decision to decrease concurrency is made, ensuring that only sustained negative trends lead to a scale down.
 // - If the standard deviation is below or equal to the threshold, suggesting stable response times, and the system is currently operating below its concurrency capacity, it may suggest an increase in concurrency to improve throughput.
 //
 // This approach aims to prevent transient spikes in response times from causing undue scaling actions, thus stabilizing the overall performance and responsiveness of the system.
@@ -226,9 +224,9 @@ func (ch *ConcurrencyHandler) MonitorResponseTimeVariability(responseTime time.D
 	averageResponseTime := calculateAverage(responseTimes)
 
 	// Multi-factor check before scaling down
-	if stdDev > ch.Metrics.ResponseTimeVariability.StdDevThreshold && averageResponseTime > AcceptableAverageResponseTime {
+	if stdDev > ch.Metrics.ResponseTimeVariability.StdDevThreshold && averageResponseTime > ch.tuning.AcceptableAverageResponseTime {
 		ch.Metrics.ResponseTimeVariability.DebounceScaleDownCount++
-		if ch.Metrics.ResponseTimeVariability.DebounceScaleDownCount >= debounceScaleDownThreshold {
+		if ch.Metrics.ResponseTimeVariability.DebounceScaleDownCount >= ch.tuning.DebounceScaleDownThreshold {
 			ch.Metrics.ResponseTimeVariability.DebounceScaleDownCount = 0
 			return -1 // Suggest decrease concurrency
 		}
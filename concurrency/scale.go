@@ -1,37 +1,39 @@
 // concurrency/scale.go
 package concurrency
 
-import "go.uber.org/zap"
+import (
+	"time"
 
-// ScaleDown reduces the concurrency level by one, down to the minimum limit.
-func (ch *ConcurrencyHandler) ScaleDown() {
-	// Lock to ensure thread safety
-	ch.lock.Lock()
-	defer ch.lock.Unlock()
+	"go.uber.org/zap"
+)
 
-	// We must consider the capacity rather than the length of the semaphore channel
-	currentSize := cap(ch.sem)
-	if currentSize > MinConcurrency {
-		newSize := currentSize - 1
-		ch.logger.Info("Reducing request concurrency", zap.Int("currentSize", currentSize), zap.Int("newSize", newSize))
-		ch.ResizeSemaphore(newSize)
-	} else {
-		ch.logger.Info("Concurrency already at minimum level; cannot reduce further", zap.Int("currentSize", currentSize))
-	}
+// ScaleDown lowers the concurrency limit as the limit algorithm does on overload, down to its minimum.
+func (ch *ConcurrencyHandler) ScaleDown() {
+	ch.adjustLimit(0, true)
 }
 
-// ScaleUp increases the concurrency level by one, up to the maximum limit.
+// ScaleUp raises the concurrency limit as the limit algorithm does after a healthy request, up to its maximum.
+// AIMD only raises the limit while at least half of it is in use.
 func (ch *ConcurrencyHandler) ScaleUp() {
+	ch.adjustLimit(0, false)
+}
+
+// adjustLimit passes the outcome of a request to the limit algorithm and applies the new limit.
+func (ch *ConcurrencyHandler) adjustLimit(latency time.Duration, overloaded bool) {
 	// Lock to ensure thread safety
 	ch.lock.Lock()
 	defer ch.lock.Unlock()
 
-	currentSize := cap(ch.sem)
-	if currentSize < MaxConcurrency {
-		newSize := currentSize + 1
+	currentSize := ch.limiter.Limit()
+	newSize := ch.Algorithm.Update(currentSize, ch.limiter.InUse(), latency, overloaded)
+	switch {
+	case newSize < currentSize:
+		ch.logger.Info("Reducing request concurrency", zap.Int("currentSize", currentSize), zap.Int("newSize", newSize))
+	case newSize > currentSize:
 		ch.logger.Info("Increasing request concurrency", zap.Int("currentSize", currentSize), zap.Int("newSize", newSize))
-		ch.ResizeSemaphore(newSize)
-	} else {
-		ch.logger.Info("Concurrency already at maximum level; cannot increase further", zap.Int("currentSize", currentSize))
+	default:
+		ch.logger.Debug("Request concurrency unchanged", zap.Int("currentSize", currentSize), zap.Bool("overloaded", overloaded))
+		return
 	}
+	ch.limiter.SetLimit(newSize)
 }
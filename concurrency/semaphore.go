@@ -6,8 +6,11 @@ package concurrency
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -24,15 +27,33 @@ import (
 //   - context.Context: A new context derived from the original, including a unique request ID.
 //     This context is used to trace and manage operations under the acquired concurrency permit.
 //   - uuid.UUID: The unique request ID generated during the permit acquisition process.
-//   - error: An error object that indicates failure to acquire a permit within the allotted
-//     timeout, or other system-related issues.
+//   - error: An error object that indicates failure to acquire a permit within the permit
+//     timeout of the handler's Tuning, wrapping apierrors.ErrPermitTimeout, or the cancellation of ctx.
 //
 // Usage:
 // This function should be used before initiating any operation that requires concurrency control.
 // The returned context should be passed to subsequent operations to maintain consistency in
 // concurrency tracking.
 func (ch *ConcurrencyHandler) AcquireConcurrencyPermit(ctx context.Context) (context.Context, uuid.UUID, error) {
+	return ch.AcquireWeightedConcurrencyPermit(ctx, 1)
+}
+
+// AcquireWeightedConcurrencyPermit acquires a permit counting as weight requests against the concurrency limit, for
+// expensive calls such as large exports. A permit heavier than the limit is granted once no other permit is held.
+// It otherwise behaves as AcquireConcurrencyPermit.
+func (ch *ConcurrencyHandler) AcquireWeightedConcurrencyPermit(ctx context.Context, weight int) (context.Context, uuid.UUID, error) {
+	return ch.AcquirePermit(ctx, PermitRequest{Weight: weight})
+}
+
+// AcquirePermit acquires a permit with the weight, priority and caller of request. Waiting requests are served
+// highest priority first and round robin across callers. When the wait queue is full, the request is shed with an
+// error wrapping apierrors.ErrOverloaded unless it displaces a waiting request of a lower priority.
+// It otherwise behaves as AcquireConcurrencyPermit.
+func (ch *ConcurrencyHandler) AcquirePermit(ctx context.Context, request PermitRequest) (context.Context, uuid.UUID, error) {
 	log := ch.logger
+	if request.Weight < 1 {
+		request.Weight = 1
+	}
 
 	// Start measuring the permit acquisition time.
 	tokenAcquisitionStart := time.Now()
@@ -40,24 +61,30 @@ func (ch *ConcurrencyHandler) AcquireConcurrencyPermit(ctx context.Context) (con
 	// Generate a unique request ID for this permit acquisition.
 	requestID := uuid.New()
 
-	// Create a new context with a specified timeout for acquiring the permit.
-	ctxWithTimeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	// Create a new context with the configured timeout for acquiring the permit.
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, ch.tuning.PermitTimeout)
 	defer cancel() // Ensure to free up resources by cancelling the context after use.
 
-	select {
-	case ch.sem <- struct{}{}: // permit acquisition was successful.
-		// Record the time taken to acquire the permit.
-		tokenAcquisitionDuration := time.Since(tokenAcquisitionStart)
-		ch.trackResourceAcquisition(tokenAcquisitionDuration, requestID) // Track and log metrics.
+	if err := ch.limiter.AcquirePermit(ctxWithTimeout, request); err != nil {
+		if errors.Is(err, apierrors.ErrOverloaded) {
+			log.Warn("Shed request, too many requests waiting for a concurrency permit", zap.String("priority", request.Priority.String()), zap.String("caller", request.Caller))
+			return ctx, requestID, err
+		}
+		// Timeout occurred before a permit could be acquired.
+		log.Error("Failed to acquire concurrency permit", zap.Error(err))
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("%w: %w", apierrors.ErrPermitTimeout, err)
+		}
+		return ctx, requestID, err
+	}
 
-		// Create a new context that includes the unique request ID.
-		ctxWithRequestID := context.WithValue(ctx, RequestIDKey{}, requestID)
-		return ctxWithRequestID, requestID, nil
+	// Record the time taken to acquire the permit.
+	tokenAcquisitionDuration := time.Since(tokenAcquisitionStart)
+	ch.trackResourceAcquisition(tokenAcquisitionDuration, requestID, request.Weight) // Track and log metrics.
 
-	case <-ctxWithTimeout.Done(): // Timeout occurred before a permit could be acquired.
-		log.Error("Failed to acquire concurrency permit", zap.Error(ctxWithTimeout.Err()))
-		return ctx, requestID, ctxWithTimeout.Err()
-	}
+	// Create a new context that includes the unique request ID.
+	ctxWithRequestID := context.WithValue(ctx, RequestIDKey{}, requestID)
+	return ctxWithRequestID, requestID, nil
 }
 
 // trackResourceAcquisition logs and updates metrics associated with the acquisition of concurrency tokens.
@@ -70,10 +97,12 @@ func (ch *ConcurrencyHandler) AcquireConcurrencyPermit(ctx context.Context) (con
 //
 // This method locks the concurrency handler to safely update shared metrics and logs detailed
 // information about the permit acquisition for debugging and monitoring purposes.
-func (ch *ConcurrencyHandler) trackResourceAcquisition(duration time.Duration, requestID uuid.UUID) {
+func (ch *ConcurrencyHandler) trackResourceAcquisition(duration time.Duration, requestID uuid.UUID, weight int) {
 	ch.lock.Lock()
 	defer ch.lock.Unlock()
 
+	ch.permits[requestID] = weight
+
 	// Record the time taken to acquire the permit and update related metrics.
 	ch.AcquisitionTimes = append(ch.AcquisitionTimes, duration)
 	ch.Metrics.Lock.Lock()
@@ -82,9 +111,21 @@ func (ch *ConcurrencyHandler) trackResourceAcquisition(duration time.Duration, r
 	ch.Metrics.Lock.Unlock()
 
 	// Calculate and log the current state of permit utilization.
-	utilizedPermits := len(ch.sem)
-	availablePermits := cap(ch.sem) - utilizedPermits
-	ch.logger.Debug("Resource acquired", zap.String("RequestID", requestID.String()), zap.Duration("Duration", duration), zap.Int("UtilizedPermits", utilizedPermits), zap.Int("AvailablePermits", availablePermits))
+	utilizedPermits := ch.limiter.InUse()
+	availablePermits := ch.limiter.Limit() - utilizedPermits
+	ch.logger.Debug("Resource acquired", zap.String("RequestID", requestID.String()), zap.Int("Weight", weight), zap.Duration("Duration", duration), zap.Int("UtilizedPermits", utilizedPermits), zap.Int("AvailablePermits", availablePermits))
+}
+
+// TryAcquireConcurrencyPermit acquires a concurrency permit only if one is free, without waiting. It is used for
+// optional work, such as hedged requests, that should be skipped rather than queued when the client is busy.
+// The permit must be released with ReleaseConcurrencyPermit.
+func (ch *ConcurrencyHandler) TryAcquireConcurrencyPermit() (uuid.UUID, bool) {
+	requestID := uuid.New()
+	if !ch.limiter.TryAcquire(1) {
+		return requestID, false
+	}
+	ch.trackResourceAcquisition(0, requestID, 1)
+	return requestID, true
 }
 
 // ReleaseConcurrencyPermit releases a concurrency permit back to the semaphore, making it available for other
@@ -105,30 +146,31 @@ func (ch *ConcurrencyHandler) trackResourceAcquisition(duration time.Duration, r
 // This usage ensures that the permit is released in a deferred manner at the end of the operation, regardless of
 // how the operation exits (normal completion or error path).
 func (ch *ConcurrencyHandler) ReleaseConcurrencyPermit(requestID uuid.UUID) {
-	// Safely remove a permit from the semaphore to make it available for other operations.
-	select {
-	case <-ch.sem:
-		// Continue to process after successfully retrieving a permit from the semaphore.
-	default:
-		// Log an error if no permit was available to release, indicating a potential synchronization issue.
+	ch.lock.Lock()
+	defer ch.lock.Unlock()
+
+	// Safely return the permit to the limiter to make it available for other operations.
+	weight, ok := ch.permits[requestID]
+	if !ok {
+		// Log an error if no permit was held, indicating a potential synchronization issue.
 		ch.logger.Error("Attempted to release a non-existent concurrency permit", zap.String("RequestID", requestID.String()))
 		return
 	}
-
-	ch.lock.Lock()
-	defer ch.lock.Unlock()
+	delete(ch.permits, requestID)
+	ch.limiter.Release(weight)
 
 	// Update metrics related to permit release.
 	ch.Metrics.Lock.Lock()
 	ch.Metrics.TotalRequests-- // Decrement the count of total requests handled, if applicable.
 	ch.Metrics.Lock.Unlock()
 
-	utilizedPermits := len(ch.sem)                    // Calculate tokens currently in use.
-	availablePermits := cap(ch.sem) - utilizedPermits // Calculate tokens that are available for use.
+	utilizedPermits := ch.limiter.InUse()                    // Calculate tokens currently in use.
+	availablePermits := ch.limiter.Limit() - utilizedPermits // Calculate tokens that are available for use.
 
 	// Log the release of the concurrency permit for auditing and debugging purposes.
 	ch.logger.Debug("Released concurrency permit",
 		zap.String("RequestID", requestID.String()),
+		zap.Int("Weight", weight),
 		zap.Int("UtilizedPermits", utilizedPermits),
 		zap.Int("AvailablePermits", availablePermits),
 	)
@@ -0,0 +1,114 @@
+// concurrency/tuning.go
+package concurrency
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Tuning holds the settings by which the ConcurrencyHandler waits for permits and adjusts the concurrency limit.
+// Zero fields take their value from DefaultTuning.
+type Tuning struct {
+	PermitTimeout                 time.Duration // Longest wait for a concurrency permit
+	MinConcurrency                int           // Lowest concurrency limit
+	MaxConcurrency                int           // Highest concurrency limit, raised to the initial limit if that is higher
+	ErrorRateThreshold            float64       // Share of error responses above which concurrency is scaled down
+	ErrorResponseThreshold        float64       // Weighted error score at or above which concurrency is scaled down at once
+	RateLimitCriticalThreshold    int           // Rate limit feedback at or below which the error score is checked
+	ResponseTimeCriticalThreshold time.Duration // Response time treated as overload
+	AcceptableAverageResponseTime time.Duration // Average response time above which variable response times scale down
+	ResponseTimeStdDevThreshold   time.Duration // Standard deviation of response times treated as unstable
+	DebounceScaleDownThreshold    int           // Consecutive unstable response time checks before scaling down
+}
+
+// DefaultTuning returns the tuning used for fields left zero, built from the package constants.
+func DefaultTuning() Tuning {
+	return Tuning{
+		PermitTimeout:                 DefaultPermitTimeout,
+		MinConcurrency:                MinConcurrency,
+		MaxConcurrency:                MaxConcurrency,
+		ErrorRateThreshold:            ErrorRateThreshold,
+		ErrorResponseThreshold:        ErrorResponseThreshold,
+		RateLimitCriticalThreshold:    RateLimitCriticalThreshold,
+		ResponseTimeCriticalThreshold: ResponseTimeCriticalThreshold,
+		AcceptableAverageResponseTime: AcceptableAverageResponseTime,
+		ResponseTimeStdDevThreshold:   MaxAcceptableResponseTimeVariability,
+		DebounceScaleDownThreshold:    debounceScaleDownThreshold,
+	}
+}
+
+// WithDefaults returns a copy of t whose zero fields are taken from defaults.
+func (t Tuning) WithDefaults(defaults Tuning) Tuning {
+	if t.PermitTimeout == 0 {
+		t.PermitTimeout = defaults.PermitTimeout
+	}
+	if t.MinConcurrency == 0 {
+		t.MinConcurrency = defaults.MinConcurrency
+	}
+	if t.MaxConcurrency == 0 {
+		t.MaxConcurrency = defaults.MaxConcurrency
+	}
+	if t.ErrorRateThreshold == 0 {
+		t.ErrorRateThreshold = defaults.ErrorRateThreshold
+	}
+	if t.ErrorResponseThreshold == 0 {
+		t.ErrorResponseThreshold = defaults.ErrorResponseThreshold
+	}
+	if t.RateLimitCriticalThreshold == 0 {
+		t.RateLimitCriticalThreshold = defaults.RateLimitCriticalThreshold
+	}
+	if t.ResponseTimeCriticalThreshold == 0 {
+		t.ResponseTimeCriticalThreshold = defaults.ResponseTimeCriticalThreshold
+	}
+	if t.AcceptableAverageResponseTime == 0 {
+		t.AcceptableAverageResponseTime = defaults.AcceptableAverageResponseTime
+	}
+	if t.ResponseTimeStdDevThreshold == 0 {
+		t.ResponseTimeStdDevThreshold = defaults.ResponseTimeStdDevThreshold
+	}
+	if t.DebounceScaleDownThreshold == 0 {
+		t.DebounceScaleDownThreshold = defaults.DebounceScaleDownThreshold
+	}
+	return t
+}
+
+// Validate checks that the fields set are in range and that MinConcurrency does not exceed MaxConcurrency.
+// Zero fields are accepted, as they take their default.
+func (t Tuning) Validate() error {
+	var errs []error
+	if t.PermitTimeout < 0 {
+		errs = append(errs, fmt.Errorf("PermitTimeout must not be negative, got: %s", t.PermitTimeout))
+	}
+	if t.MinConcurrency < 0 {
+		errs = append(errs, fmt.Errorf("MinConcurrency must not be negative, got: %d", t.MinConcurrency))
+	}
+	if t.MaxConcurrency < 0 {
+		errs = append(errs, fmt.Errorf("MaxConcurrency must not be negative, got: %d", t.MaxConcurrency))
+	}
+	if t.MinConcurrency > 0 && t.MaxConcurrency > 0 && t.MinConcurrency > t.MaxConcurrency {
+		errs = append(errs, fmt.Errorf("MinConcurrency %d must not exceed MaxConcurrency %d", t.MinConcurrency, t.MaxConcurrency))
+	}
+	if t.ErrorRateThreshold < 0 || t.ErrorRateThreshold > 1 {
+		errs = append(errs, fmt.Errorf("ErrorRateThreshold must be between 0 and 1, got: %g", t.ErrorRateThreshold))
+	}
+	if t.ErrorResponseThreshold < 0 {
+		errs = append(errs, fmt.Errorf("ErrorResponseThreshold must not be negative, got: %g", t.ErrorResponseThreshold))
+	}
+	if t.RateLimitCriticalThreshold < 0 {
+		errs = append(errs, fmt.Errorf("RateLimitCriticalThreshold must not be negative, got: %d", t.RateLimitCriticalThreshold))
+	}
+	if t.ResponseTimeCriticalThreshold < 0 {
+		errs = append(errs, fmt.Errorf("ResponseTimeCriticalThreshold must not be negative, got: %s", t.ResponseTimeCriticalThreshold))
+	}
+	if t.AcceptableAverageResponseTime < 0 {
+		errs = append(errs, fmt.Errorf("AcceptableAverageResponseTime must not be negative, got: %s", t.AcceptableAverageResponseTime))
+	}
+	if t.ResponseTimeStdDevThreshold < 0 {
+		errs = append(errs, fmt.Errorf("ResponseTimeStdDevThreshold must not be negative, got: %s", t.ResponseTimeStdDevThreshold))
+	}
+	if t.DebounceScaleDownThreshold < 0 {
+		errs = append(errs, fmt.Errorf("DebounceScaleDownThreshold must not be negative, got: %d", t.DebounceScaleDownThreshold))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,56 @@
+// concurrency/tuning_test.go
+package concurrency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTuningWithDefaults tests that only the zero fields take their default.
+func TestTuningWithDefaults(t *testing.T) {
+	tuning := Tuning{PermitTimeout: time.Second, MaxConcurrency: 3}.WithDefaults(DefaultTuning())
+
+	assert.Equal(t, time.Second, tuning.PermitTimeout)
+	assert.Equal(t, 3, tuning.MaxConcurrency)
+	assert.Equal(t, MinConcurrency, tuning.MinConcurrency)
+	assert.Equal(t, MaxAcceptableResponseTimeVariability, tuning.ResponseTimeStdDevThreshold)
+	assert.Equal(t, debounceScaleDownThreshold, tuning.DebounceScaleDownThreshold)
+}
+
+// TestTuningValidate tests that out of range fields are rejected and zero fields accepted.
+func TestTuningValidate(t *testing.T) {
+	assert.NoError(t, Tuning{}.Validate())
+	assert.NoError(t, DefaultTuning().Validate())
+
+	err := Tuning{PermitTimeout: -time.Second, ErrorRateThreshold: 1.5}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "PermitTimeout")
+	assert.Contains(t, err.Error(), "ErrorRateThreshold")
+
+	assert.ErrorContains(t, Tuning{MinConcurrency: 5, MaxConcurrency: 2}.Validate(), "must not exceed MaxConcurrency")
+}
+
+// TestConcurrencyHandlerTuning tests that the handler waits for permits and bounds its limit by its tuning.
+func TestConcurrencyHandlerTuning(t *testing.T) {
+	log := logger.BuildLogger(logger.LogLevelError, "console", " ", "")
+	metrics := &ConcurrencyMetrics{}
+	ch := NewConcurrencyHandlerWithTuning(1, log, metrics, Tuning{PermitTimeout: 20 * time.Millisecond, MaxConcurrency: 2})
+
+	assert.Equal(t, MaxAcceptableResponseTimeVariability.Seconds(), metrics.ResponseTimeVariability.StdDevThreshold)
+	assert.Equal(t, AIMD{MinLimit: MinConcurrency, MaxLimit: 2, LatencyThreshold: ResponseTimeCriticalThreshold}, ch.Algorithm)
+
+	_, requestID, err := ch.AcquireConcurrencyPermit(context.Background())
+	require.NoError(t, err)
+	defer ch.ReleaseConcurrencyPermit(requestID)
+
+	start := time.Now()
+	_, _, err = ch.AcquireConcurrencyPermit(context.Background())
+	assert.ErrorIs(t, err, apierrors.ErrPermitTimeout)
+	assert.Less(t, time.Since(start), time.Second)
+}
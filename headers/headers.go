@@ -35,7 +35,7 @@ func NewHeaderHandler(req *http.Request, log logger.Logger, apiHandler apihandle
 
 // SetAuthorization sets the Authorization header for the request.
 func (h *HeaderHandler) SetAuthorization() {
-	token := h.authTokenHandler.Token
+	token := h.authTokenHandler.GetToken()
 	if !strings.HasPrefix(token, "Bearer ") {
 		token = "Bearer " + token
 	}
@@ -3,8 +3,10 @@ package helpers
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -33,3 +35,35 @@ func SafeOpenFile(filePath string) (*os.File, error) {
 	// Open the file if the path is deemed safe
 	return os.Open(absPath)
 }
+
+// JoinURL appends an endpoint path to a base URL such as "https://jamf.corp:8443/" or "http://127.0.0.1:9000/prefix",
+// so that exactly one slash separates the two.
+func JoinURL(baseURL, endpointPath string) string {
+	if endpointPath == "" {
+		return strings.TrimSuffix(baseURL, "/")
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/" + strings.TrimPrefix(endpointPath, "/")
+}
+
+// AppendQuery appends an encoded query string to an endpoint path, e.g. "/users" and "$top=5" become
+// "/users?$top=5". Paths that already carry a query are extended with "&"; an empty query leaves the path unchanged.
+func AppendQuery(endpointPath, query string) string {
+	if query == "" {
+		return endpointPath
+	}
+	if strings.Contains(endpointPath, "?") {
+		return endpointPath + "&" + query
+	}
+	return endpointPath + "?" + query
+}
+
+// EscapeQueryValue percent-encodes a query parameter value, using %20 rather than + for spaces, as query languages
+// such as OData and RSQL expect.
+func EscapeQueryValue(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+// EscapeQuotes escapes backslashes and double quotes with a backslash, for values written inside double quotes.
+func EscapeQuotes(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
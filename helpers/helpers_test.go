@@ -44,3 +44,41 @@ func TestParseISO8601Date(t *testing.T) {
 		})
 	}
 }
+
+// TestJoinURL tests the JoinURL function with various base URLs and endpoint paths
+func TestJoinURL(t *testing.T) {
+	tests := []struct {
+		baseURL      string
+		endpointPath string
+		expected     string
+	}{
+		{"https://jamf.corp:8443", "/api/v1/auth/token", "https://jamf.corp:8443/api/v1/auth/token"},
+		{"https://jamf.corp:8443/", "/api/v1/auth/token", "https://jamf.corp:8443/api/v1/auth/token"},
+		{"http://127.0.0.1:9000/prefix/", "api/v1/devices", "http://127.0.0.1:9000/prefix/api/v1/devices"},
+		{"https://proxy.corp/graph/", "", "https://proxy.corp/graph"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.baseURL+tt.endpointPath, func(t *testing.T) {
+			assert.Equal(t, tt.expected, JoinURL(tt.baseURL, tt.endpointPath))
+		})
+	}
+}
+
+// TestAppendQuery tests the AppendQuery function with and without an existing query
+func TestAppendQuery(t *testing.T) {
+	assert.Equal(t, "/users", AppendQuery("/users", ""))
+	assert.Equal(t, "/users?$top=5", AppendQuery("/users", "$top=5"))
+	assert.Equal(t, "/users?$count=true&$top=5", AppendQuery("/users?$count=true", "$top=5"))
+}
+
+// TestEscapeQueryValue tests that spaces are encoded as %20 and reserved characters are percent-encoded
+func TestEscapeQueryValue(t *testing.T) {
+	assert.Equal(t, "name%20eq%20%27a%26b%27", EscapeQueryValue("name eq 'a&b'"))
+	assert.Equal(t, "general.name%3D%3D%22x%2Cy%22", EscapeQueryValue(`general.name=="x,y"`))
+}
+
+// TestEscapeQuotes tests that backslashes and double quotes are escaped
+func TestEscapeQuotes(t *testing.T) {
+	assert.Equal(t, `CORP\\bob \"Bob\"`, EscapeQuotes(`CORP\bob "Bob"`))
+}
@@ -13,7 +13,9 @@ package httpclient
 
 import (
 	"errors"
+	"fmt"
 
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/apihandler"
 	"github.com/deploymenttheory/go-api-http-client/authenticationhandler"
 )
 
@@ -24,6 +26,27 @@ func DetermineAuthMethod(authConfig AuthConfig) (string, error) {
 	// Initialize validation flags as true
 	validClientID, validClientSecret, validUsername, validPassword := true, true, true, true
 	clientIDErrMsg, clientSecretErrMsg, usernameErrMsg, passwordErrMsg := "", "", "", ""
+	validAppID, validPrivateKey := true, true
+	appIDErrMsg, privateKeyErrMsg, installationErrMsg := "", "", ""
+
+	// Validate GitHub App credentials if provided, as they are the only credentials that identify an app installation
+	if authConfig.GitHubAppID != "" || authConfig.GitHubAppPrivateKey != "" {
+		validAppID, appIDErrMsg = authenticationhandler.IsValidGitHubAppID(authConfig.GitHubAppID)
+		validPrivateKey, privateKeyErrMsg = authenticationhandler.IsValidGitHubAppPrivateKey(authConfig.GitHubAppPrivateKey)
+		hasInstallation := authConfig.GitHubAppInstallationID > 0 || authConfig.GitHubAppInstallationOwner != ""
+		if !hasInstallation {
+			installationErrMsg = "GitHub App installation ID or installation owner must be provided."
+		}
+		// If the app credentials are valid and the installation can be identified, use GitHub App authentication
+		if validAppID && validPrivateKey && hasInstallation {
+			return "githubapp", nil
+		}
+	}
+
+	// A refresh token is exchanged with the client ID and secret in the format of the provider issuing it, e.g. GitHub's
+	if authConfig.RefreshToken != "" && authConfig.ClientID != "" && authConfig.ClientSecret != "" {
+		return "oauth2", nil
+	}
 
 	// Validate ClientID and ClientSecret for OAuth if provided
 	if authConfig.ClientID != "" || authConfig.ClientSecret != "" {
@@ -47,6 +70,15 @@ func DetermineAuthMethod(authConfig AuthConfig) (string, error) {
 
 	// Construct an error message if any of the provided fields are invalid
 	errorMsg := "No valid credentials provided."
+	if !validAppID && authConfig.GitHubAppID != "" {
+		errorMsg += " " + appIDErrMsg
+	}
+	if !validPrivateKey && authConfig.GitHubAppPrivateKey != "" {
+		errorMsg += " " + privateKeyErrMsg
+	}
+	if installationErrMsg != "" {
+		errorMsg += " " + installationErrMsg
+	}
 	if !validClientID && authConfig.ClientID != "" {
 		errorMsg += " " + clientIDErrMsg
 	}
@@ -62,3 +94,25 @@ func DetermineAuthMethod(authConfig AuthConfig) (string, error) {
 
 	return "unknown", errors.New(errorMsg)
 }
+
+// validateAuthMethodSupport checks that the api handler supports the authentication method determined from the
+// credentials, so that unsupported credentials fail when the client is built rather than on the first request.
+func validateAuthMethodSupport(apiHandler apihandler.APIHandler, authMethod string, authConfig AuthConfig) error {
+	supported := true
+	switch authMethod {
+	case "oauth2":
+		supported = apiHandler.GetAPIOAuthAuthenticationSupportStatus()
+		if requirer, ok := apiHandler.(apihandler.OAuthRefreshTokenRequirer); ok && supported && requirer.RequiresOAuthRefreshToken() && authConfig.RefreshToken == "" {
+			return fmt.Errorf("authentication method %q requires a refresh token for the api handler, which has no client credentials grant", authMethod)
+		}
+	case "basicauth":
+		supported = apiHandler.GetAPIBearerTokenAuthenticationSupportStatus()
+	case "githubapp":
+		supporter, ok := apiHandler.(apihandler.GitHubAppAuthenticationSupporter)
+		supported = ok && supporter.GetAPIGitHubAppAuthenticationSupportStatus()
+	}
+	if !supported {
+		return fmt.Errorf("authentication method %q is not supported by the api handler", authMethod)
+	}
+	return nil
+}
@@ -3,7 +3,10 @@ package httpclient
 import (
 	"testing"
 
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/apihandler"
+	"github.com/deploymenttheory/go-api-http-client/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDetermineAuthMethod(t *testing.T) {
@@ -70,3 +73,34 @@ func TestDetermineAuthMethod(t *testing.T) {
 		})
 	}
 }
+
+// TestValidateAuthMethodSupport tests that credentials the api handler cannot authenticate with are rejected, such as
+// OAuth client credentials without a refresh token for GitHub, which has no client credentials grant.
+func TestValidateAuthMethodSupport(t *testing.T) {
+	log := logger.BuildLogger(logger.LogLevelError, "console", " ", "")
+	gitHubHandler, err := apihandler.NewAPIHandler(apihandler.HandlerConfig{APIType: "github", Logger: log})
+	require.NoError(t, err)
+	jamfHandler, err := apihandler.NewAPIHandler(apihandler.HandlerConfig{APIType: "jamfpro", InstanceName: "acme", Logger: log})
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, validateAuthMethodSupport(gitHubHandler, "oauth2", AuthConfig{}), "requires a refresh token")
+	assert.NoError(t, validateAuthMethodSupport(gitHubHandler, "oauth2", AuthConfig{RefreshToken: "ghr_refresh"}))
+	assert.ErrorContains(t, validateAuthMethodSupport(gitHubHandler, "basicauth", AuthConfig{}), "not supported")
+	assert.NoError(t, validateAuthMethodSupport(gitHubHandler, "githubapp", AuthConfig{}))
+	assert.NoError(t, validateAuthMethodSupport(jamfHandler, "oauth2", AuthConfig{}))
+	assert.NoError(t, validateAuthMethodSupport(jamfHandler, "basicauth", AuthConfig{}))
+	assert.ErrorContains(t, validateAuthMethodSupport(jamfHandler, "githubapp", AuthConfig{}), "not supported")
+}
+
+// TestDetermineAuthMethodRefreshToken tests that client IDs and secrets in a provider's own format are accepted
+// along with a refresh token, as GitHub issues them.
+func TestDetermineAuthMethodRefreshToken(t *testing.T) {
+	authConfig := AuthConfig{ClientID: "Iv23liAbCdEf", ClientSecret: "0123456789abcdef0123456789abcdef01234567"}
+	_, err := DetermineAuthMethod(authConfig)
+	assert.Error(t, err)
+
+	authConfig.RefreshToken = "ghr_refresh"
+	authMethod, err := DetermineAuthMethod(authConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "oauth2", authMethod)
+}
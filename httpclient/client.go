@@ -13,13 +13,19 @@ import (
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/deploymenttheory/go-api-http-client/apiintegrations/apihandler"
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/endpointconfig"
 	"github.com/deploymenttheory/go-api-http-client/authenticationhandler"
+	"github.com/deploymenttheory/go-api-http-client/cachehandler"
+	"github.com/deploymenttheory/go-api-http-client/circuitbreaker"
 	"github.com/deploymenttheory/go-api-http-client/concurrency"
 
 	"github.com/deploymenttheory/go-api-http-client/logger"
+	"github.com/deploymenttheory/go-api-http-client/ratehandler"
 	"github.com/deploymenttheory/go-api-http-client/redirecthandler"
 	"go.uber.org/zap"
 )
@@ -35,6 +41,13 @@ type Client struct {
 	ConcurrencyHandler *concurrency.ConcurrencyHandler         // ConcurrencyHandler for managing concurrent requests
 	APIHandler         apihandler.APIHandler                   // APIHandler interface used to define which API handler to use
 	AuthTokenHandler   *authenticationhandler.AuthTokenHandler // AuthTokenHandler for managing authentication
+	credentialsLock    sync.RWMutex                            // Guards clientConfig.Auth and AuthMethod against concurrent credential updates
+	inflight           requestGroup                            // Identical GET requests in flight, shared when coalescing is enabled
+	CircuitBreakers    *circuitbreaker.Group                   // Circuit breakers of the hosts or endpoint groups requested, nil when disabled
+	RateLimiter        *ratehandler.Limiter                    // Client side request rate limiter, nil when no limit is configured
+	Pacer              *ratehandler.Pacer                      // Paces requests by the rate limit budgets servers report, nil when dynamic rate limiting is disabled
+	RetryPolicy        ratehandler.RetryPolicy                 // Decides which failed attempts of idempotent requests are retried and how long to wait
+	hedger             *hedger                                 // Sends second attempts of slow GET requests, nil when hedging is disabled
 }
 
 // Config holds configuration options for the HTTP Client.
@@ -46,29 +59,44 @@ type ClientConfig struct {
 
 // AuthConfig represents the structure to read authentication details from a JSON configuration file.
 type AuthConfig struct {
-	Username     string `json:"Username,omitempty"`
-	Password     string `json:"Password,omitempty"`
-	ClientID     string `json:"ClientID,omitempty"`
-	ClientSecret string `json:"ClientSecret,omitempty"`
+	Username                        string `json:"Username,omitempty"`
+	Password                        string `json:"Password,omitempty"`
+	ClientID                        string `json:"ClientID,omitempty"`
+	ClientSecret                    string `json:"ClientSecret,omitempty"`
+	GitHubAppID                     string `json:"GitHubAppID,omitempty"`                     // GitHub App ID (or client ID) used to sign the app JWT
+	GitHubAppPrivateKey             string `json:"GitHubAppPrivateKey,omitempty"`             // PEM encoded GitHub App private key
+	GitHubAppPrivateKeyPath         string `json:"GitHubAppPrivateKeyPath,omitempty"`         // Path to the PEM encoded GitHub App private key, used when GitHubAppPrivateKey is empty
+	GitHubAppInstallationID         int64  `json:"GitHubAppInstallationID,omitempty"`         // GitHub App installation to authenticate as; looked up from the owner when zero
+	GitHubAppInstallationOwner      string `json:"GitHubAppInstallationOwner,omitempty"`      // Organization or user used to look up the installation
+	GitHubAppInstallationRepository string `json:"GitHubAppInstallationRepository,omitempty"` // Repository of the owner used to look up the installation
+	RefreshToken                    string `json:"RefreshToken,omitempty"`                    // OAuth refresh token exchanged with ClientID and ClientSecret for access tokens; required for GitHub
 }
 
 // EnvironmentConfig represents the structure to read authentication details from a JSON configuration file.
 type EnvironmentConfig struct {
-	APIType            string `json:"APIType,omitempty"`            // APIType specifies the type of API integration to use
-	InstanceName       string `json:"InstanceName,omitempty"`       // Website Instance name without the root domain
-	OverrideBaseDomain string `json:"OverrideBaseDomain,omitempty"` // Base domain override used when the default in the api handler isn't suitable
-	TenantID           string `json:"TenantID,omitempty"`           // TenantID is the unique identifier for the tenant
-	TenantName         string `json:"TenantName,omitempty"`         // TenantName is the name of the tenant
+	APIType               string          `json:"APIType,omitempty"`               // APIType specifies the type of API integration to use
+	InstanceName          string          `json:"InstanceName,omitempty"`          // Website Instance name without the root domain
+	OverrideBaseDomain    string          `json:"OverrideBaseDomain,omitempty"`    // Base domain override used when the default in the api handler isn't suitable
+	BaseURL               string          `json:"BaseURL,omitempty"`               // Full base URL (scheme, host, port and path prefix) for resource endpoints, e.g. https://jamf.corp:8443
+	AuthBaseURL           string          `json:"AuthBaseURL,omitempty"`           // Full base URL for authentication endpoints; the api handler's default is used when empty
+	TenantID              string          `json:"TenantID,omitempty"`              // TenantID is the unique identifier for the tenant
+	TenantName            string          `json:"TenantName,omitempty"`            // TenantName is the name of the tenant
+	APIOptions            json.RawMessage `json:"APIOptions,omitempty"`            // APIOptions holds settings specific to the selected api handler
+	EndpointOverridesPath string          `json:"EndpointOverridesPath,omitempty"` // Path to a JSON file of per-endpoint Accept and Content-Type rules merged over the api handler's built-in rules
 }
 
 // ClientOptions holds optional configuration options for the HTTP Client.
 type ClientOptions struct {
-	Logging     LoggingConfig     // Configuration related to logging
-	Cookies     CookieConfig      // Cookie handling settings
-	Retry       RetryConfig       // Retry behavior configuration
-	Concurrency ConcurrencyConfig // Concurrency configuration
-	Timeout     TimeoutConfig     // Custom timeout settings
-	Redirect    RedirectConfig    // Redirect handling settings
+	Logging        LoggingConfig        // Configuration related to logging
+	Cookies        CookieConfig         // Cookie handling settings
+	Retry          RetryConfig          // Retry behavior configuration
+	Concurrency    ConcurrencyConfig    // Concurrency configuration
+	Timeout        TimeoutConfig        // Custom timeout settings
+	Redirect       RedirectConfig       // Redirect handling settings
+	Cache          CacheConfig          // Response cache settings
+	CircuitBreaker CircuitBreakerConfig // Circuit breaker settings
+	RateLimit      RateLimitConfig      // Client side request rate limits
+	Hedging        HedgingConfig        // Hedged GET request settings
 }
 
 // LoggingConfig holds configuration options related to logging.
@@ -88,13 +116,46 @@ type CookieConfig struct {
 
 // RetryConfig holds configuration related to retry behavior.
 type RetryConfig struct {
-	MaxRetryAttempts          int  // Maximum number of retry request attempts for retryable HTTP methods.
-	EnableDynamicRateLimiting bool // Whether dynamic rate limiting should be enabled.
+	MaxRetryAttempts          int                     // Maximum number of retry request attempts for retryable HTTP methods.
+	EnableDynamicRateLimiting bool                    // Whether requests should be paced by the rate limit headers of the responses.
+	BackoffStrategy           string                  // Backoff between retries: "exponential" (default), "decorrelated" or "constant"
+	BaseDelay                 time.Duration           // First retry delay, or the constant delay; defaults to 100ms
+	MaxDelay                  time.Duration           // Longest retry delay; defaults to 5s
+	Policy                    ratehandler.RetryPolicy `json:"-"` // Custom retry policy used instead of the settings above
 }
 
 // ConcurrencyConfig holds configuration related to concurrency management.
+// The tuning fields left zero take the api handler's default, if it has one, or the concurrency package default.
 type ConcurrencyConfig struct {
-	MaxConcurrentRequests int // Maximum number of concurrent requests allowed.
+	MaxConcurrentRequests         int           // Maximum number of concurrent requests allowed.
+	EnableRequestCoalescing       bool          // Share one in-flight response between concurrent identical GET requests.
+	MaxQueueDepth                 int           // Maximum requests waiting for a concurrency permit; further requests are shed. Zero for no maximum.
+	PermitTimeout                 time.Duration // Longest wait for a concurrency permit; defaults to 10s
+	MinConcurrency                int           // Lowest the concurrency limit is scaled down to; defaults to 1
+	MaxConcurrency                int           // Highest the concurrency limit is scaled up to, at least MaxConcurrentRequests; defaults to 10
+	ErrorRateThreshold            float64       // Share of error responses, between 0 and 1, above which concurrency is scaled down; defaults to 0.1
+	ErrorResponseThreshold        float64       // Weighted error score at or above which concurrency is scaled down at once; defaults to 0.2
+	RateLimitCriticalThreshold    int           // Rate limit feedback at or below which the error score is checked; defaults to 5
+	ResponseTimeCriticalThreshold time.Duration // Response time treated as overload; defaults to 2s
+	AcceptableAverageResponseTime time.Duration // Average response time above which unstable response times scale down; defaults to 100ms
+	ResponseTimeStdDevThreshold   time.Duration // Standard deviation of response times treated as unstable; defaults to 500ms
+	DebounceScaleDownThreshold    int           // Consecutive unstable response time checks before scaling down; defaults to 5
+}
+
+// tuning returns the concurrency tuning set in the configuration.
+func (c ConcurrencyConfig) tuning() concurrency.Tuning {
+	return concurrency.Tuning{
+		PermitTimeout:                 c.PermitTimeout,
+		MinConcurrency:                c.MinConcurrency,
+		MaxConcurrency:                c.MaxConcurrency,
+		ErrorRateThreshold:            c.ErrorRateThreshold,
+		ErrorResponseThreshold:        c.ErrorResponseThreshold,
+		RateLimitCriticalThreshold:    c.RateLimitCriticalThreshold,
+		ResponseTimeCriticalThreshold: c.ResponseTimeCriticalThreshold,
+		AcceptableAverageResponseTime: c.AcceptableAverageResponseTime,
+		ResponseTimeStdDevThreshold:   c.ResponseTimeStdDevThreshold,
+		DebounceScaleDownThreshold:    c.DebounceScaleDownThreshold,
+	}
 }
 
 // TimeoutConfig holds custom timeout settings.
@@ -110,6 +171,39 @@ type RedirectConfig struct {
 	MaxRedirects    int  // Maximum number of redirects to follow
 }
 
+// CacheConfig holds configuration related to caching GET responses.
+type CacheConfig struct {
+	EnableCache     bool               // Enable or disable the response cache
+	CacheStore      string             // Store for cached responses: "memory" (default) or "disk"
+	CacheDirectory  string             // Directory used by the disk store
+	MaxCacheEntries int                // Maximum number of responses kept by the memory store, unbounded when 0
+	StaleIfError    time.Duration      // How long past freshness a cached response may be served when the server fails
+	Store           cachehandler.Store `json:"-"` // Custom store, used instead of CacheStore when set
+}
+
+// CircuitBreakerConfig holds configuration related to failing fast when a host or endpoint group keeps failing.
+type CircuitBreakerConfig struct {
+	EnableCircuitBreaker bool          // Enable or disable circuit breakers
+	KeyBy                string        // Scope of each circuit breaker: "host" (default) or "endpoint" for endpoint groups
+	FailureThreshold     int           // Consecutive connection errors or transient error responses that open a circuit breaker
+	CoolDown             time.Duration // How long an open circuit breaker fails requests before letting probe requests through
+	HalfOpenMaxRequests  int           // Probe requests allowed in flight after the cool-down
+}
+
+// HedgingConfig holds configuration related to sending a second attempt of slow GET requests.
+type HedgingConfig struct {
+	EnableHedging bool          // Enable or disable hedged GET requests
+	HedgeDelay    time.Duration // Wait before hedging; zero uses the 95th percentile of recent GET latencies
+	HedgeBudget   float64       // Share of GET requests that may be hedged; defaults to 0.1
+}
+
+// RateLimitConfig holds configuration related to limiting the request rate before requests are sent.
+type RateLimitConfig struct {
+	RequestsPerSecond float64                          // Sustained request rate for the client; zero means unlimited
+	Burst             int                              // Requests allowed at once after a quiet period; defaults to RequestsPerSecond rounded up
+	EndpointLimits    map[string]ratehandler.RateLimit `json:"EndpointLimits,omitempty"` // Limits per endpoint group, keyed by path pattern as in endpoint overrides
+}
+
 // BuildClient creates a new HTTP client with the provided configuration.
 func BuildClient(config ClientConfig) (*Client, error) {
 
@@ -122,32 +216,57 @@ func BuildClient(config ClientConfig) (*Client, error) {
 	// Set the logger's level (optional if BuildLogger already sets the level based on the input)
 	log.SetLevel(parsedLogLevel)
 
+	// Load the user supplied endpoint rules, which are merged over the api handler's built-in rules
+	var endpointOverrides endpointconfig.ConfigMap
+	if config.Environment.EndpointOverridesPath != "" {
+		var err error
+		endpointOverrides, err = endpointconfig.LoadFromFile(config.Environment.EndpointOverridesPath)
+		if err != nil {
+			log.Error("Failed to load endpoint overrides", zap.String("EndpointOverridesPath", config.Environment.EndpointOverridesPath), zap.Error(err))
+			return nil, err
+		}
+	}
+
 	// Use the APIType from the config to determine which API handler to load
-	apiHandler, err := apihandler.LoadAPIHandler(config.Environment.APIType, config.Environment.InstanceName, config.Environment.TenantID, config.Environment.TenantName, log)
+	apiHandler, err := apihandler.NewAPIHandler(apihandler.HandlerConfig{
+		APIType:            config.Environment.APIType,
+		InstanceName:       config.Environment.InstanceName,
+		OverrideBaseDomain: config.Environment.OverrideBaseDomain,
+		BaseURL:            config.Environment.BaseURL,
+		AuthBaseURL:        config.Environment.AuthBaseURL,
+		TenantID:           config.Environment.TenantID,
+		TenantName:         config.Environment.TenantName,
+		Options:            config.Environment.APIOptions,
+		EndpointOverrides:  endpointOverrides,
+		Logger:             log,
+	})
 	if err != nil {
 		log.Error("Failed to load API handler", zap.String("APIType", config.Environment.APIType), zap.Error(err))
 		return nil, err
 	}
 
+	// Load the GitHub App private key from disk if only a path was supplied
+	if err := loadGitHubAppPrivateKey(&config.Auth); err != nil {
+		log.Error("Failed to load GitHub App private key", zap.Error(err))
+		return nil, err
+	}
+
 	// Determine the authentication method using the helper function
 	authMethod, err := DetermineAuthMethod(config.Auth)
 	if err != nil {
 		log.Error("Failed to determine authentication method", zap.Error(err))
 		return nil, err
 	}
-
-	// Initialize AuthTokenHandler
-	clientCredentials := authenticationhandler.ClientCredentials{
-		Username:     config.Auth.Username,
-		Password:     config.Auth.Password,
-		ClientID:     config.Auth.ClientID,
-		ClientSecret: config.Auth.ClientSecret,
+	if err := validateAuthMethodSupport(apiHandler, authMethod, config.Auth); err != nil {
+		log.Error("Unsupported authentication method", zap.String("AuthMethod", authMethod), zap.Error(err))
+		return nil, err
 	}
 
+	// Initialize AuthTokenHandler
 	authTokenHandler := authenticationhandler.NewAuthTokenHandler(
 		log,
 		authMethod,
-		clientCredentials,
+		newClientCredentials(config.Auth),
 		config.Environment.InstanceName,
 		config.ClientOptions.Logging.HideSensitiveData,
 	)
@@ -171,15 +290,42 @@ func BuildClient(config ClientConfig) (*Client, error) {
 		return nil, err
 	}
 
-	// Initialize ConcurrencyMetrics specifically for ConcurrencyHandler
-	concurrencyMetrics := &concurrency.ConcurrencyMetrics{}
+	// Conditionally pace requests by the rate limit budgets the servers report
+	var pacer *ratehandler.Pacer
+	if config.ClientOptions.Retry.EnableDynamicRateLimiting {
+		pacer = ratehandler.NewPacer(0, log)
+		if baseURL, err := url.Parse(apiHandler.ConstructAPIResourceEndpoint("", log)); err == nil {
+			pacer.BasePath = baseURL.Path
+		}
+		httpClient.Transport = ratehandler.NewPacingTransport(httpClient.Transport, pacer)
+	}
 
-	// Initialize the ConcurrencyHandler with the newly created ConcurrencyMetrics
-	concurrencyHandler := concurrency.NewConcurrencyHandler(
-		config.ClientOptions.Concurrency.MaxConcurrentRequests,
-		log,
-		concurrencyMetrics,
-	)
+	// Conditionally setup circuit breakers, beneath the response cache so that stale responses can be served while open
+	circuitBreakers, err := SetupCircuitBreaker(httpClient, config, log)
+	if err != nil {
+		log.Error("Error setting up circuit breaker", zap.Error(err))
+		return nil, err
+	}
+
+	// Conditionally setup the response cache
+	if err := SetupCache(httpClient, config, log); err != nil {
+		log.Error("Error setting up response cache", zap.Error(err))
+		return nil, err
+	}
+
+	// Build the retry policy used unless a request overrides it
+	retryPolicy, err := SetupRetryPolicy(config)
+	if err != nil {
+		log.Error("Error setting up retry policy", zap.Error(err))
+		return nil, err
+	}
+
+	// Initialize the ConcurrencyHandler, tuned for the API unless configured otherwise
+	concurrencyHandler, err := SetupConcurrencyHandler(config, apiHandler, log)
+	if err != nil {
+		log.Error("Error setting up concurrency handler", zap.Error(err))
+		return nil, err
+	}
 
 	// Create a new HTTP client with the provided configuration.
 	client := &Client{
@@ -190,6 +336,19 @@ func BuildClient(config ClientConfig) (*Client, error) {
 		Logger:             log,
 		ConcurrencyHandler: concurrencyHandler,
 		AuthTokenHandler:   authTokenHandler,
+		CircuitBreakers:    circuitBreakers,
+		Pacer:              pacer,
+		RetryPolicy:        retryPolicy,
+		hedger:             newHedger(config.ClientOptions.Hedging),
+		RateLimiter: ratehandler.NewLimiter(ratehandler.RateLimit{
+			RequestsPerSecond: config.ClientOptions.RateLimit.RequestsPerSecond,
+			Burst:             config.ClientOptions.RateLimit.Burst,
+		}, config.ClientOptions.RateLimit.EndpointLimits),
+	}
+
+	// Cache responses per identity, so that credentials swapped by UpdateCredentials never see another identity's responses
+	if cache, ok := httpClient.Transport.(*cachehandler.Transport); ok {
+		cache.Identity = client.cacheIdentity
 	}
 
 	// Log the client's configuration.
@@ -207,9 +366,17 @@ func BuildClient(config ClientConfig) (*Client, error) {
 		zap.Bool("Cookie Jar Enabled", config.ClientOptions.Cookies.EnableCookieJar),
 		zap.Int("Max Retry Attempts", config.ClientOptions.Retry.MaxRetryAttempts),
 		zap.Bool("Enable Dynamic Rate Limiting", config.ClientOptions.Retry.EnableDynamicRateLimiting),
+		zap.String("Backoff Strategy", config.ClientOptions.Retry.BackoffStrategy),
 		zap.Int("Max Concurrent Requests", config.ClientOptions.Concurrency.MaxConcurrentRequests),
+		zap.Duration("Concurrency Permit Timeout", concurrencyHandler.Tuning().PermitTimeout),
+		zap.Bool("Request Coalescing Enabled", config.ClientOptions.Concurrency.EnableRequestCoalescing),
 		zap.Bool("Follow Redirects", config.ClientOptions.Redirect.FollowRedirects),
 		zap.Int("Max Redirects", config.ClientOptions.Redirect.MaxRedirects),
+		zap.Bool("Response Cache Enabled", config.ClientOptions.Cache.EnableCache),
+		zap.Bool("Circuit Breaker Enabled", config.ClientOptions.CircuitBreaker.EnableCircuitBreaker),
+		zap.Float64("Rate Limit Requests Per Second", config.ClientOptions.RateLimit.RequestsPerSecond),
+		zap.Int("Rate Limit Burst", config.ClientOptions.RateLimit.Burst),
+		zap.Int("Endpoint Rate Limits", len(config.ClientOptions.RateLimit.EndpointLimits)),
 		zap.Duration("Token Refresh Buffer Period", config.ClientOptions.Timeout.TokenRefreshBufferPeriod),
 		zap.Duration("Total Retry Duration", config.ClientOptions.Timeout.TotalRetryDuration),
 		zap.Duration("Custom Timeout", config.ClientOptions.Timeout.CustomTimeout),
@@ -239,7 +406,11 @@ func SetupCookieJar(client *http.Client, clientConfig ClientConfig, log logger.L
 				CookieList = append(CookieList, newCookie)
 			}
 
-			cookieUrl, err := url.Parse(fmt.Sprintf("https://%s.jamfcloud.com", clientConfig.Environment.InstanceName))
+			cookieBaseURL := fmt.Sprintf("https://%s.jamfcloud.com", clientConfig.Environment.InstanceName)
+			if clientConfig.Environment.BaseURL != "" {
+				cookieBaseURL = clientConfig.Environment.BaseURL
+			}
+			cookieUrl, err := url.Parse(cookieBaseURL)
 			if err != nil {
 				return err
 			}
@@ -256,3 +427,112 @@ func SetupCookieJar(client *http.Client, clientConfig ClientConfig, log logger.L
 	}
 	return nil
 }
+
+// SetupCache wraps the client's transport with a response cache for GET requests when enabled.
+func SetupCache(client *http.Client, clientConfig ClientConfig, log logger.Logger) error {
+	cacheConfig := clientConfig.ClientOptions.Cache
+	if !cacheConfig.EnableCache {
+		return nil
+	}
+
+	store := cacheConfig.Store
+	if store == nil {
+		switch strings.ToLower(cacheConfig.CacheStore) {
+		case "", "memory":
+			store = cachehandler.NewMemoryStore(cacheConfig.MaxCacheEntries)
+		case "disk":
+			diskStore, err := cachehandler.NewDiskStore(cacheConfig.CacheDirectory)
+			if err != nil {
+				return fmt.Errorf("setupCache failed: %w", err)
+			}
+			store = diskStore
+		default:
+			return fmt.Errorf("setupCache failed: unsupported cache store %q, expected \"memory\" or \"disk\"", cacheConfig.CacheStore)
+		}
+	}
+
+	client.Transport = cachehandler.NewTransport(client.Transport, store, cacheConfig.StaleIfError, log)
+	log.Info("Response cache enabled", zap.String("store", cacheConfig.CacheStore), zap.Duration("stale_if_error", cacheConfig.StaleIfError))
+	return nil
+}
+
+// SetupCircuitBreaker wraps the client's transport with circuit breakers when enabled and returns them.
+func SetupCircuitBreaker(client *http.Client, clientConfig ClientConfig, log logger.Logger) (*circuitbreaker.Group, error) {
+	breakerConfig := clientConfig.ClientOptions.CircuitBreaker
+	if !breakerConfig.EnableCircuitBreaker {
+		return nil, nil
+	}
+
+	var keyFunc circuitbreaker.KeyFunc
+	switch strings.ToLower(breakerConfig.KeyBy) {
+	case "", "host":
+		keyFunc = circuitbreaker.HostKey
+	case "endpoint":
+		keyFunc = circuitbreaker.EndpointGroupKey
+	default:
+		return nil, fmt.Errorf("setupCircuitBreaker failed: unsupported key %q, expected \"host\" or \"endpoint\"", breakerConfig.KeyBy)
+	}
+
+	group := circuitbreaker.NewGroup(circuitbreaker.Config{
+		FailureThreshold:    breakerConfig.FailureThreshold,
+		CoolDown:            breakerConfig.CoolDown,
+		HalfOpenMaxRequests: breakerConfig.HalfOpenMaxRequests,
+	}, keyFunc, log)
+	client.Transport = circuitbreaker.NewTransport(client.Transport, group)
+	log.Info("Circuit breaker enabled", zap.String("key_by", breakerConfig.KeyBy), zap.Int("failure_threshold", breakerConfig.FailureThreshold), zap.Duration("cool_down", breakerConfig.CoolDown))
+	return group, nil
+}
+
+// SetupRetryPolicy returns the configured retry policy, or a policy retrying retryable status codes with the
+// configured backoff strategy.
+func SetupRetryPolicy(clientConfig ClientConfig) (ratehandler.RetryPolicy, error) {
+	retryConfig := clientConfig.ClientOptions.Retry
+	if retryConfig.Policy != nil {
+		return retryConfig.Policy, nil
+	}
+
+	strategy, err := ratehandler.NewBackoffStrategy(retryConfig.BackoffStrategy, retryConfig.BaseDelay, retryConfig.MaxDelay)
+	if err != nil {
+		return nil, fmt.Errorf("setupRetryPolicy failed: %w", err)
+	}
+	return ratehandler.NewRetryPolicy(strategy), nil
+}
+
+// SetupConcurrencyHandler validates the configured concurrency tuning and returns a ConcurrencyHandler using it.
+// Tuning fields left zero take the api handler's defaults when it implements apihandler.ConcurrencyDefaultsProvider,
+// and the concurrency package defaults otherwise.
+func SetupConcurrencyHandler(clientConfig ClientConfig, apiHandler apihandler.APIHandler, log logger.Logger) (*concurrency.ConcurrencyHandler, error) {
+	concurrencyConfig := clientConfig.ClientOptions.Concurrency
+	tuning := concurrencyConfig.tuning()
+	// MaxConcurrency is raised to the initial limit, so MinConcurrency is checked against the raised ceiling
+	if tuning.MaxConcurrency > 0 {
+		tuning.MaxConcurrency = max(concurrencyConfig.MaxConcurrentRequests, tuning.MaxConcurrency)
+	}
+	if err := tuning.Validate(); err != nil {
+		return nil, fmt.Errorf("setupConcurrencyHandler failed: %w", err)
+	}
+
+	defaults := concurrency.DefaultTuning()
+	if provider, ok := apiHandler.(apihandler.ConcurrencyDefaultsProvider); ok {
+		apiDefaults := provider.ConcurrencyDefaults()
+		if err := apiDefaults.Validate(); err != nil {
+			return nil, fmt.Errorf("setupConcurrencyHandler failed: invalid api handler defaults: %w", err)
+		}
+		defaults = apiDefaults.WithDefaults(defaults)
+	}
+	tuning = tuning.WithDefaults(defaults)
+	maxConcurrency := max(concurrencyConfig.MaxConcurrentRequests, tuning.MaxConcurrency)
+	if tuning.MinConcurrency > maxConcurrency {
+		return nil, fmt.Errorf("setupConcurrencyHandler failed: MinConcurrency %d must not exceed MaxConcurrency %d", tuning.MinConcurrency, maxConcurrency)
+	}
+
+	concurrencyHandler := concurrency.NewConcurrencyHandlerWithTuning(concurrencyConfig.MaxConcurrentRequests, log, &concurrency.ConcurrencyMetrics{}, tuning)
+	concurrencyHandler.SetMaxQueueDepth(concurrencyConfig.MaxQueueDepth)
+	log.Debug("Concurrency handler tuned",
+		zap.Duration("permit_timeout", tuning.PermitTimeout),
+		zap.Int("min_concurrency", tuning.MinConcurrency),
+		zap.Int("max_concurrency", maxConcurrency),
+		zap.Duration("response_time_critical_threshold", tuning.ResponseTimeCriticalThreshold),
+	)
+	return concurrencyHandler, nil
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -102,6 +103,27 @@ func LoadConfigFromEnv(config *ClientConfig) (*ClientConfig, error) {
 	config.Auth.ClientSecret = getEnvOrDefault("CLIENT_SECRET", config.Auth.ClientSecret)
 	log.Printf("ClientSecret env value found and set")
 
+	config.Auth.RefreshToken = getEnvOrDefault("REFRESH_TOKEN", config.Auth.RefreshToken)
+	log.Printf("RefreshToken env value found and set")
+
+	config.Auth.GitHubAppID = getEnvOrDefault("GITHUB_APP_ID", config.Auth.GitHubAppID)
+	log.Printf("GitHubAppID env value found and set to: %s", config.Auth.GitHubAppID)
+
+	config.Auth.GitHubAppPrivateKey = getEnvOrDefault("GITHUB_APP_PRIVATE_KEY", config.Auth.GitHubAppPrivateKey)
+	log.Printf("GitHubAppPrivateKey env value found and set")
+
+	config.Auth.GitHubAppPrivateKeyPath = getEnvOrDefault("GITHUB_APP_PRIVATE_KEY_PATH", config.Auth.GitHubAppPrivateKeyPath)
+	log.Printf("GitHubAppPrivateKeyPath env value found and set to: %s", config.Auth.GitHubAppPrivateKeyPath)
+
+	config.Auth.GitHubAppInstallationID = parseInt64(getEnvOrDefault("GITHUB_APP_INSTALLATION_ID", strconv.FormatInt(config.Auth.GitHubAppInstallationID, 10)), config.Auth.GitHubAppInstallationID)
+	log.Printf("GitHubAppInstallationID env value found and set to: %d", config.Auth.GitHubAppInstallationID)
+
+	config.Auth.GitHubAppInstallationOwner = getEnvOrDefault("GITHUB_APP_INSTALLATION_OWNER", config.Auth.GitHubAppInstallationOwner)
+	log.Printf("GitHubAppInstallationOwner env value found and set to: %s", config.Auth.GitHubAppInstallationOwner)
+
+	config.Auth.GitHubAppInstallationRepository = getEnvOrDefault("GITHUB_APP_INSTALLATION_REPOSITORY", config.Auth.GitHubAppInstallationRepository)
+	log.Printf("GitHubAppInstallationRepository env value found and set to: %s", config.Auth.GitHubAppInstallationRepository)
+
 	// EnvironmentConfig
 	config.Environment.APIType = getEnvOrDefault("API_TYPE", config.Environment.APIType)
 	log.Printf("APIType env value found and set to: %s", config.Environment.APIType)
@@ -112,6 +134,15 @@ func LoadConfigFromEnv(config *ClientConfig) (*ClientConfig, error) {
 	config.Environment.OverrideBaseDomain = getEnvOrDefault("OVERRIDE_BASE_DOMAIN", config.Environment.OverrideBaseDomain)
 	log.Printf("OverrideBaseDomain env value found and set to: %s", config.Environment.OverrideBaseDomain)
 
+	config.Environment.BaseURL = getEnvOrDefault("BASE_URL", config.Environment.BaseURL)
+	log.Printf("BaseURL env value found and set to: %s", config.Environment.BaseURL)
+
+	config.Environment.AuthBaseURL = getEnvOrDefault("AUTH_BASE_URL", config.Environment.AuthBaseURL)
+	log.Printf("AuthBaseURL env value found and set to: %s", config.Environment.AuthBaseURL)
+
+	config.Environment.EndpointOverridesPath = getEnvOrDefault("ENDPOINT_OVERRIDES_PATH", config.Environment.EndpointOverridesPath)
+	log.Printf("EndpointOverridesPath env value found and set to: %s", config.Environment.EndpointOverridesPath)
+
 	config.Environment.TenantID = getEnvOrDefault("TENANT_ID", config.Environment.TenantID)
 	log.Printf("TenantID env value found and set to: %s", config.Environment.TenantID)
 
@@ -154,10 +185,55 @@ func LoadConfigFromEnv(config *ClientConfig) (*ClientConfig, error) {
 	config.ClientOptions.Retry.EnableDynamicRateLimiting = parseBool(getEnvOrDefault("ENABLE_DYNAMIC_RATE_LIMITING", strconv.FormatBool(config.ClientOptions.Retry.EnableDynamicRateLimiting)))
 	log.Printf("EnableDynamicRateLimiting env value found and set to: %t", config.ClientOptions.Retry.EnableDynamicRateLimiting)
 
+	config.ClientOptions.Retry.BackoffStrategy = getEnvOrDefault("RETRY_BACKOFF_STRATEGY", config.ClientOptions.Retry.BackoffStrategy)
+	log.Printf("BackoffStrategy env value set to: %s", config.ClientOptions.Retry.BackoffStrategy)
+
+	config.ClientOptions.Retry.BaseDelay = parseDuration(getEnvOrDefault("RETRY_BASE_DELAY", config.ClientOptions.Retry.BaseDelay.String()), 0)
+	log.Printf("Retry BaseDelay env value set to: %s", config.ClientOptions.Retry.BaseDelay)
+
+	config.ClientOptions.Retry.MaxDelay = parseDuration(getEnvOrDefault("RETRY_MAX_DELAY", config.ClientOptions.Retry.MaxDelay.String()), 0)
+	log.Printf("Retry MaxDelay env value set to: %s", config.ClientOptions.Retry.MaxDelay)
+
 	// Concurrency
 	config.ClientOptions.Concurrency.MaxConcurrentRequests = parseInt(getEnvOrDefault("MAX_CONCURRENT_REQUESTS", strconv.Itoa(config.ClientOptions.Concurrency.MaxConcurrentRequests)), DefaultMaxConcurrentRequests)
 	log.Printf("MaxConcurrentRequests env value found and set to: %d", config.ClientOptions.Concurrency.MaxConcurrentRequests)
 
+	config.ClientOptions.Concurrency.EnableRequestCoalescing = parseBool(getEnvOrDefault("ENABLE_REQUEST_COALESCING", strconv.FormatBool(config.ClientOptions.Concurrency.EnableRequestCoalescing)))
+	log.Printf("EnableRequestCoalescing env value found and set to: %t", config.ClientOptions.Concurrency.EnableRequestCoalescing)
+
+	config.ClientOptions.Concurrency.MaxQueueDepth = parseInt(getEnvOrDefault("MAX_QUEUE_DEPTH", strconv.Itoa(config.ClientOptions.Concurrency.MaxQueueDepth)), 0)
+	log.Printf("MaxQueueDepth env value set to: %d", config.ClientOptions.Concurrency.MaxQueueDepth)
+
+	config.ClientOptions.Concurrency.PermitTimeout = parseDuration(getEnvOrDefault("CONCURRENCY_PERMIT_TIMEOUT", config.ClientOptions.Concurrency.PermitTimeout.String()), 0)
+	log.Printf("PermitTimeout env value set to: %s", config.ClientOptions.Concurrency.PermitTimeout)
+
+	config.ClientOptions.Concurrency.MinConcurrency = parseInt(getEnvOrDefault("MIN_CONCURRENCY", strconv.Itoa(config.ClientOptions.Concurrency.MinConcurrency)), 0)
+	log.Printf("MinConcurrency env value set to: %d", config.ClientOptions.Concurrency.MinConcurrency)
+
+	config.ClientOptions.Concurrency.MaxConcurrency = parseInt(getEnvOrDefault("MAX_CONCURRENCY", strconv.Itoa(config.ClientOptions.Concurrency.MaxConcurrency)), 0)
+	log.Printf("MaxConcurrency env value set to: %d", config.ClientOptions.Concurrency.MaxConcurrency)
+
+	config.ClientOptions.Concurrency.ErrorRateThreshold = parseFloat(getEnvOrDefault("ERROR_RATE_THRESHOLD", strconv.FormatFloat(config.ClientOptions.Concurrency.ErrorRateThreshold, 'f', -1, 64)), 0)
+	log.Printf("ErrorRateThreshold env value set to: %g", config.ClientOptions.Concurrency.ErrorRateThreshold)
+
+	config.ClientOptions.Concurrency.ErrorResponseThreshold = parseFloat(getEnvOrDefault("ERROR_RESPONSE_THRESHOLD", strconv.FormatFloat(config.ClientOptions.Concurrency.ErrorResponseThreshold, 'f', -1, 64)), 0)
+	log.Printf("ErrorResponseThreshold env value set to: %g", config.ClientOptions.Concurrency.ErrorResponseThreshold)
+
+	config.ClientOptions.Concurrency.RateLimitCriticalThreshold = parseInt(getEnvOrDefault("RATE_LIMIT_CRITICAL_THRESHOLD", strconv.Itoa(config.ClientOptions.Concurrency.RateLimitCriticalThreshold)), 0)
+	log.Printf("RateLimitCriticalThreshold env value set to: %d", config.ClientOptions.Concurrency.RateLimitCriticalThreshold)
+
+	config.ClientOptions.Concurrency.ResponseTimeCriticalThreshold = parseDuration(getEnvOrDefault("RESPONSE_TIME_CRITICAL_THRESHOLD", config.ClientOptions.Concurrency.ResponseTimeCriticalThreshold.String()), 0)
+	log.Printf("ResponseTimeCriticalThreshold env value set to: %s", config.ClientOptions.Concurrency.ResponseTimeCriticalThreshold)
+
+	config.ClientOptions.Concurrency.AcceptableAverageResponseTime = parseDuration(getEnvOrDefault("ACCEPTABLE_AVERAGE_RESPONSE_TIME", config.ClientOptions.Concurrency.AcceptableAverageResponseTime.String()), 0)
+	log.Printf("AcceptableAverageResponseTime env value set to: %s", config.ClientOptions.Concurrency.AcceptableAverageResponseTime)
+
+	config.ClientOptions.Concurrency.ResponseTimeStdDevThreshold = parseDuration(getEnvOrDefault("RESPONSE_TIME_STDDEV_THRESHOLD", config.ClientOptions.Concurrency.ResponseTimeStdDevThreshold.String()), 0)
+	log.Printf("ResponseTimeStdDevThreshold env value set to: %s", config.ClientOptions.Concurrency.ResponseTimeStdDevThreshold)
+
+	config.ClientOptions.Concurrency.DebounceScaleDownThreshold = parseInt(getEnvOrDefault("DEBOUNCE_SCALE_DOWN_THRESHOLD", strconv.Itoa(config.ClientOptions.Concurrency.DebounceScaleDownThreshold)), 0)
+	log.Printf("DebounceScaleDownThreshold env value set to: %d", config.ClientOptions.Concurrency.DebounceScaleDownThreshold)
+
 	// timeouts
 	config.ClientOptions.Timeout.TokenRefreshBufferPeriod = parseDuration(getEnvOrDefault("TOKEN_REFRESH_BUFFER_PERIOD", config.ClientOptions.Timeout.TokenRefreshBufferPeriod.String()), DefaultTokenBufferPeriod)
 	log.Printf("TokenRefreshBufferPeriod env value found and set to: %s", config.ClientOptions.Timeout.TokenRefreshBufferPeriod)
@@ -175,6 +251,55 @@ func LoadConfigFromEnv(config *ClientConfig) (*ClientConfig, error) {
 	config.ClientOptions.Redirect.MaxRedirects = parseInt(getEnvOrDefault("MAX_REDIRECTS", strconv.Itoa(config.ClientOptions.Redirect.MaxRedirects)), MaxRedirects)
 	log.Printf("MaxRedirects env value set to: %d", config.ClientOptions.Redirect.MaxRedirects)
 
+	// Cache
+	config.ClientOptions.Cache.EnableCache = parseBool(getEnvOrDefault("ENABLE_CACHE", strconv.FormatBool(config.ClientOptions.Cache.EnableCache)))
+	log.Printf("EnableCache env value set to: %t", config.ClientOptions.Cache.EnableCache)
+
+	config.ClientOptions.Cache.CacheStore = getEnvOrDefault("CACHE_STORE", config.ClientOptions.Cache.CacheStore)
+	log.Printf("CacheStore env value set to: %s", config.ClientOptions.Cache.CacheStore)
+
+	config.ClientOptions.Cache.CacheDirectory = getEnvOrDefault("CACHE_DIRECTORY", config.ClientOptions.Cache.CacheDirectory)
+	log.Printf("CacheDirectory env value set to: %s", config.ClientOptions.Cache.CacheDirectory)
+
+	config.ClientOptions.Cache.MaxCacheEntries = parseInt(getEnvOrDefault("MAX_CACHE_ENTRIES", strconv.Itoa(config.ClientOptions.Cache.MaxCacheEntries)), 0)
+	log.Printf("MaxCacheEntries env value set to: %d", config.ClientOptions.Cache.MaxCacheEntries)
+
+	config.ClientOptions.Cache.StaleIfError = parseDuration(getEnvOrDefault("CACHE_STALE_IF_ERROR", config.ClientOptions.Cache.StaleIfError.String()), 0)
+	log.Printf("StaleIfError env value set to: %s", config.ClientOptions.Cache.StaleIfError)
+
+	// Circuit breaker
+	config.ClientOptions.CircuitBreaker.EnableCircuitBreaker = parseBool(getEnvOrDefault("ENABLE_CIRCUIT_BREAKER", strconv.FormatBool(config.ClientOptions.CircuitBreaker.EnableCircuitBreaker)))
+	log.Printf("EnableCircuitBreaker env value set to: %t", config.ClientOptions.CircuitBreaker.EnableCircuitBreaker)
+
+	config.ClientOptions.CircuitBreaker.KeyBy = getEnvOrDefault("CIRCUIT_BREAKER_KEY_BY", config.ClientOptions.CircuitBreaker.KeyBy)
+	log.Printf("CircuitBreaker KeyBy env value set to: %s", config.ClientOptions.CircuitBreaker.KeyBy)
+
+	config.ClientOptions.CircuitBreaker.FailureThreshold = parseInt(getEnvOrDefault("CIRCUIT_BREAKER_FAILURE_THRESHOLD", strconv.Itoa(config.ClientOptions.CircuitBreaker.FailureThreshold)), 0)
+	log.Printf("CircuitBreaker FailureThreshold env value set to: %d", config.ClientOptions.CircuitBreaker.FailureThreshold)
+
+	config.ClientOptions.CircuitBreaker.CoolDown = parseDuration(getEnvOrDefault("CIRCUIT_BREAKER_COOL_DOWN", config.ClientOptions.CircuitBreaker.CoolDown.String()), 0)
+	log.Printf("CircuitBreaker CoolDown env value set to: %s", config.ClientOptions.CircuitBreaker.CoolDown)
+
+	config.ClientOptions.CircuitBreaker.HalfOpenMaxRequests = parseInt(getEnvOrDefault("CIRCUIT_BREAKER_HALF_OPEN_MAX_REQUESTS", strconv.Itoa(config.ClientOptions.CircuitBreaker.HalfOpenMaxRequests)), 0)
+	log.Printf("CircuitBreaker HalfOpenMaxRequests env value set to: %d", config.ClientOptions.CircuitBreaker.HalfOpenMaxRequests)
+
+	// Rate limit
+	config.ClientOptions.RateLimit.RequestsPerSecond = parseFloat(getEnvOrDefault("RATE_LIMIT_REQUESTS_PER_SECOND", strconv.FormatFloat(config.ClientOptions.RateLimit.RequestsPerSecond, 'f', -1, 64)), 0)
+	log.Printf("RateLimit RequestsPerSecond env value set to: %g", config.ClientOptions.RateLimit.RequestsPerSecond)
+
+	config.ClientOptions.RateLimit.Burst = parseInt(getEnvOrDefault("RATE_LIMIT_BURST", strconv.Itoa(config.ClientOptions.RateLimit.Burst)), 0)
+	log.Printf("RateLimit Burst env value set to: %d", config.ClientOptions.RateLimit.Burst)
+
+	// Hedging
+	config.ClientOptions.Hedging.EnableHedging = parseBool(getEnvOrDefault("ENABLE_HEDGING", strconv.FormatBool(config.ClientOptions.Hedging.EnableHedging)))
+	log.Printf("EnableHedging env value set to: %t", config.ClientOptions.Hedging.EnableHedging)
+
+	config.ClientOptions.Hedging.HedgeDelay = parseDuration(getEnvOrDefault("HEDGE_DELAY", config.ClientOptions.Hedging.HedgeDelay.String()), 0)
+	log.Printf("HedgeDelay env value set to: %s", config.ClientOptions.Hedging.HedgeDelay)
+
+	config.ClientOptions.Hedging.HedgeBudget = parseFloat(getEnvOrDefault("HEDGE_BUDGET", strconv.FormatFloat(config.ClientOptions.Hedging.HedgeBudget, 'f', -1, 64)), 0)
+	log.Printf("HedgeBudget env value set to: %g", config.ClientOptions.Hedging.HedgeBudget)
+
 	// Set default values if necessary
 	setLoggerDefaultValues(config)
 	setClientDefaultValues(config)
@@ -193,8 +318,8 @@ func LoadConfigFromEnv(config *ClientConfig) (*ClientConfig, error) {
 func validateMandatoryConfiguration(config *ClientConfig) error {
 	var missingFields []string
 
-	// Check for mandatory fields related to the environment
-	if config.Environment.InstanceName == "" {
+	// Check for mandatory fields related to the environment. The instance name is not needed when a full base URL is given.
+	if config.Environment.InstanceName == "" && config.Environment.BaseURL == "" {
 		missingFields = append(missingFields, "Environment.InstanceName")
 	}
 	if config.Environment.APIType == "" {
@@ -212,11 +337,12 @@ func validateMandatoryConfiguration(config *ClientConfig) error {
 		missingFields = append(missingFields, "ClientOptions.Logging.LogConsoleSeparator")
 	}
 
-	// Check for either OAuth credentials pair or Username and Password pair
+	// Check for either OAuth credentials pair, Username and Password pair or GitHub App credentials
 	usingOAuth := config.Auth.ClientID != "" && config.Auth.ClientSecret != ""
 	usingBasicAuth := config.Auth.Username != "" && config.Auth.Password != ""
+	usingGitHubApp := config.Auth.GitHubAppID != "" && (config.Auth.GitHubAppPrivateKey != "" || config.Auth.GitHubAppPrivateKeyPath != "")
 
-	if !(usingOAuth || usingBasicAuth) {
+	if !(usingOAuth || usingBasicAuth || usingGitHubApp) {
 		if config.Auth.ClientID == "" {
 			missingFields = append(missingFields, "Auth.ClientID")
 		}
@@ -239,14 +365,34 @@ func validateMandatoryConfiguration(config *ClientConfig) error {
 
 	// If there are missing fields, construct and return an error message detailing what is missing
 	if len(missingFields) > 0 {
-		errorMessage := fmt.Sprintf("Mandatory configuration missing: %s. Ensure that either OAuth credentials (ClientID and ClientSecret) or Basic Auth credentials (Username and Password) are fully provided.", strings.Join(missingFields, ", "))
+		errorMessage := fmt.Sprintf("Mandatory configuration missing: %s. Ensure that either OAuth credentials (ClientID and ClientSecret), Basic Auth credentials (Username and Password) or GitHub App credentials (GitHubAppID and GitHubAppPrivateKey) are fully provided.", strings.Join(missingFields, ", "))
 		return fmt.Errorf(errorMessage)
 	}
 
+	// Base URL overrides must be absolute so that endpoint paths can be appended to them
+	if err := validateBaseURL("Environment.BaseURL", config.Environment.BaseURL); err != nil {
+		return err
+	}
+	if err := validateBaseURL("Environment.AuthBaseURL", config.Environment.AuthBaseURL); err != nil {
+		return err
+	}
+
 	// If no fields are missing, return nil indicating the configuration is complete
 	return nil
 }
 
+// validateBaseURL checks that an optional base URL override has a http or https scheme and a host.
+func validateBaseURL(field, baseURL string) error {
+	if baseURL == "" {
+		return nil
+	}
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return fmt.Errorf("%s must be an absolute http or https URL, e.g. https://jamf.corp:8443, got: %q", field, baseURL)
+	}
+	return nil
+}
+
 // setClientDefaultValues sets default values for the client configuration options if none are provided.
 // It checks each configuration option and sets it to the default value if it is either negative, zero,
 // or not set. This function ensures that the configuration adheres to expected minimums or defaults,
@@ -328,6 +474,24 @@ func parseInt(value string, defaultVal int) int {
 	return result
 }
 
+// Helper function to parse int64 from environment variable
+func parseInt64(value string, defaultVal int64) int64 {
+	result, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return defaultVal
+	}
+	return result
+}
+
+// Helper function to parse float from environment variable
+func parseFloat(value string, defaultVal float64) float64 {
+	result, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return defaultVal
+	}
+	return result
+}
+
 // Helper function to parse duration from environment variable
 func parseDuration(value string, defaultVal time.Duration) time.Duration {
 	result, err := time.ParseDuration(value)
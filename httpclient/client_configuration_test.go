@@ -95,3 +95,11 @@ func TestSetLoggerDefaultValues(t *testing.T) {
 	setLoggerDefaultValues(config)
 	assert.Equal(t, ",", config.ClientOptions.Logging.LogConsoleSeparator)
 }
+
+func TestValidateBaseURL(t *testing.T) {
+	assert.NoError(t, validateBaseURL("Environment.BaseURL", ""))
+	assert.NoError(t, validateBaseURL("Environment.BaseURL", "https://jamf.corp:8443/"))
+	assert.NoError(t, validateBaseURL("Environment.BaseURL", "http://127.0.0.1:9000/prefix"))
+	assert.Error(t, validateBaseURL("Environment.BaseURL", "jamf.corp:8443"))
+	assert.Error(t, validateBaseURL("Environment.BaseURL", "ftp://jamf.corp"))
+}
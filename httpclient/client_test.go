@@ -0,0 +1,55 @@
+// httpclient/client_test.go
+package httpclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/apihandler"
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/jamfpro"
+	"github.com/deploymenttheory/go-api-http-client/concurrency"
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSetupConcurrencyHandler tests that configured tuning overrides the api handler's defaults, which override the
+// package defaults, and that invalid tuning is rejected.
+func TestSetupConcurrencyHandler(t *testing.T) {
+	log := logger.BuildLogger(logger.LogLevelError, "console", " ", "")
+	apiHandler, err := apihandler.NewAPIHandler(apihandler.HandlerConfig{APIType: "jamfpro", InstanceName: "acme", Logger: log})
+	require.NoError(t, err)
+
+	config := ClientConfig{ClientOptions: ClientOptions{Concurrency: ConcurrencyConfig{
+		MaxConcurrentRequests: 2,
+		PermitTimeout:         time.Minute,
+	}}}
+	concurrencyHandler, err := SetupConcurrencyHandler(config, apiHandler, log)
+	require.NoError(t, err)
+	tuning := concurrencyHandler.Tuning()
+	assert.Equal(t, time.Minute, tuning.PermitTimeout)
+	assert.Equal(t, jamfpro.MaxConcurrency, tuning.MaxConcurrency)
+	assert.Equal(t, concurrency.ErrorRateThreshold, tuning.ErrorRateThreshold)
+
+	config.ClientOptions.Concurrency.MinConcurrency = 8
+	_, err = SetupConcurrencyHandler(config, apiHandler, log)
+	assert.ErrorContains(t, err, "must not exceed MaxConcurrency")
+
+	// MaxConcurrentRequests raises the ceiling above the Jamf Pro default MaxConcurrency of 5
+	config.ClientOptions.Concurrency.MaxConcurrentRequests = 10
+	_, err = SetupConcurrencyHandler(config, apiHandler, log)
+	assert.NoError(t, err)
+
+	config.ClientOptions.Concurrency.MaxConcurrency = 5
+	_, err = SetupConcurrencyHandler(config, apiHandler, log)
+	assert.NoError(t, err)
+
+	config.ClientOptions.Concurrency.MinConcurrency = 11
+	_, err = SetupConcurrencyHandler(config, apiHandler, log)
+	assert.ErrorContains(t, err, "must not exceed MaxConcurrency")
+
+	config.ClientOptions.Concurrency.MinConcurrency = 0
+	config.ClientOptions.Concurrency.ErrorRateThreshold = -1
+	_, err = SetupConcurrencyHandler(config, apiHandler, log)
+	assert.ErrorContains(t, err, "ErrorRateThreshold")
+}
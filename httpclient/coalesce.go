@@ -0,0 +1,121 @@
+// httpclient/coalesce.go
+package httpclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/deploymenttheory/go-api-http-client/response"
+	"go.uber.org/zap"
+)
+
+// requestGroup tracks the GET requests in flight so that identical concurrent requests share one network call.
+type requestGroup struct {
+	mu    sync.Mutex
+	calls map[string]*requestCall
+}
+
+// requestCall is a request in flight, or completed, along with its raw result.
+type requestCall struct {
+	done    chan struct{}
+	resp    *http.Response
+	body    []byte
+	err     error
+	callers int
+}
+
+// executeCoalescedRequest executes a GET request, sharing the response with identical requests already in flight.
+// Only the first caller acquires a concurrency permit and sends the request; callers arriving while it is in flight
+// wait for its result. The raw body is shared and every caller decodes its own copy into out, so callers never
+// observe each other's values.
+func (c *Client) executeCoalescedRequest(method, endpoint string, out interface{}) (*http.Response, error) {
+	key := c.coalescingKey(method, endpoint)
+
+	c.inflight.mu.Lock()
+	if c.inflight.calls == nil {
+		c.inflight.calls = make(map[string]*requestCall)
+	}
+	call, shared := c.inflight.calls[key]
+	if shared {
+		call.callers++
+		c.inflight.mu.Unlock()
+		c.Logger.Debug("Coalescing request with identical request in flight", zap.String("method", method), zap.String("endpoint", endpoint))
+		<-call.done
+	} else {
+		call = &requestCall{done: make(chan struct{}), callers: 1}
+		c.inflight.calls[key] = call
+		c.inflight.mu.Unlock()
+
+		func() {
+			// Release waiting callers even if the request panics
+			defer func() {
+				c.inflight.mu.Lock()
+				delete(c.inflight.calls, key)
+				c.inflight.mu.Unlock()
+				close(call.done)
+			}()
+			// A *[]byte output receives the raw body whatever its media type
+			call.resp, call.err = c.executeRequestWithRetries(method, endpoint, nil, &call.body, RequestOptions{})
+		}()
+
+		if call.callers > 1 {
+			c.Logger.Debug("Shared response with coalesced requests", zap.String("method", method), zap.String("endpoint", endpoint), zap.Int("callers", call.callers))
+		}
+	}
+
+	if call.err != nil {
+		return call.resp, call.err
+	}
+
+	resp := copyResponse(call.resp, call.body)
+	if err := response.HandleAPISuccessResponse(resp, out, c.Logger); err != nil {
+		return resp, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(call.body))
+	return resp, nil
+}
+
+// coalescingKey identifies requests that can share a response: the method, the resource URL, the headers the API
+// handler sends for the endpoint and the identity the request is authenticated as.
+func (c *Client) coalescingKey(method, endpoint string) string {
+	var key strings.Builder
+	key.WriteString(method)
+	key.WriteString(" ")
+	key.WriteString(c.APIHandler.ConstructAPIResourceEndpoint(endpoint, c.Logger))
+
+	requestHeaders := c.APIHandler.GetAPIRequestHeaders(endpoint)
+	names := make([]string, 0, len(requestHeaders))
+	for name := range requestHeaders {
+		if !strings.EqualFold(name, "Authorization") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key.WriteString("\n")
+		key.WriteString(http.CanonicalHeaderKey(name))
+		key.WriteString(": ")
+		key.WriteString(requestHeaders[name])
+	}
+
+	// The token is hashed so that it is not kept in memory in another place
+	identity := sha256.Sum256([]byte(c.authMethod() + " " + c.AuthTokenHandler.GetToken()))
+	key.WriteString("\nIdentity: ")
+	key.WriteString(hex.EncodeToString(identity[:]))
+	return key.String()
+}
+
+// copyResponse returns a copy of resp reading from body, so that each caller can read and close its own response.
+func copyResponse(resp *http.Response, body []byte) *http.Response {
+	copied := *resp
+	copied.Header = resp.Header.Clone()
+	copied.Body = io.NopCloser(bytes.NewReader(body))
+	copied.ContentLength = int64(len(body))
+	return &copied
+}
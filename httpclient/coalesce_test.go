@@ -0,0 +1,103 @@
+// httpclient/coalesce_test.go
+package httpclient
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExecuteCoalescedRequest tests that concurrent identical GET requests share one request to the server and that
+// each caller receives an independent decoded copy.
+func TestExecuteCoalescedRequest(t *testing.T) {
+	const callers = 5
+	var calls int32
+	release := make(chan struct{})
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"group","members":["a","b"]}`))
+	}, func(config *ClientConfig) {
+		config.ClientOptions.Concurrency.EnableRequestCoalescing = true
+	})
+
+	type group struct {
+		Name    string   `json:"name"`
+		Members []string `json:"members"`
+	}
+	results := make([]group, callers)
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = client.DoRequest(http.MethodGet, "/groups/1", nil, &results[i])
+		}(i)
+	}
+
+	// Hold the response until every caller is waiting on the request in flight
+	require.Eventually(t, func() bool {
+		client.inflight.mu.Lock()
+		defer client.inflight.mu.Unlock()
+		for _, call := range client.inflight.calls {
+			return call.callers == callers
+		}
+		return false
+	}, 5*time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for i := 0; i < callers; i++ {
+		require.NoError(t, errs[i])
+		assert.Equal(t, group{Name: "group", Members: []string{"a", "b"}}, results[i])
+	}
+	results[0].Members[0] = "changed"
+	assert.Equal(t, "a", results[1].Members[0])
+}
+
+// TestCoalescingKey tests that requests for different endpoints or identities are not coalesced.
+func TestCoalescingKey(t *testing.T) {
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	key := client.coalescingKey(http.MethodGet, "/groups/1")
+	assert.Equal(t, key, client.coalescingKey(http.MethodGet, "/groups/1"))
+	assert.NotEqual(t, key, client.coalescingKey(http.MethodGet, "/groups/2"))
+
+	client.AuthTokenHandler.Token = "another-token"
+	assert.NotEqual(t, key, client.coalescingKey(http.MethodGet, "/groups/1"))
+}
+
+// TestCoalescingKeyDuringCredentialUpdate tests that keys can be built while the credentials are updated. Run with
+// -race to detect unsynchronized reads of the authentication method.
+func TestCoalescingKeyDuringCredentialUpdate(t *testing.T) {
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	started := make(chan struct{})
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.coalescingKey(http.MethodGet, "/groups/1")
+		close(started)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				client.coalescingKey(http.MethodGet, "/groups/1")
+			}
+		}
+	}()
+	<-started
+	require.NoError(t, client.UpdateCredentials(AuthConfig{Username: "other", Password: "password456"}))
+	close(stop)
+	<-done
+}
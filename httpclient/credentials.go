@@ -0,0 +1,116 @@
+// httpclient/credentials.go
+package httpclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/deploymenttheory/go-api-http-client/authenticationhandler"
+	"github.com/deploymenttheory/go-api-http-client/helpers"
+	"go.uber.org/zap"
+)
+
+// UpdateCredentials swaps the credentials of a running client without rebuilding it. The new credentials are
+// validated and a token is acquired with them first; only then do they replace the credentials held in both the
+// client configuration and the AuthTokenHandler, together with the token, in a single critical section. If the
+// new credentials cannot be used the client keeps its current credentials and token and the error is returned.
+// Requests keep using the current token until the swap.
+func (c *Client) UpdateCredentials(authConfig AuthConfig) error {
+	log := c.Logger
+
+	if err := loadGitHubAppPrivateKey(&authConfig); err != nil {
+		log.Error("Failed to load GitHub App private key", zap.Error(err))
+		return err
+	}
+
+	authMethod, err := DetermineAuthMethod(authConfig)
+	if err != nil {
+		log.Error("Failed to determine authentication method for updated credentials", zap.Error(err))
+		return err
+	}
+	if err := validateAuthMethodSupport(c.APIHandler, authMethod, authConfig); err != nil {
+		log.Error("Authentication method of updated credentials not supported", zap.String("Authentication Method", authMethod), zap.Error(err))
+		return err
+	}
+
+	err = c.AuthTokenHandler.UpdateCredentials(c.APIHandler, c.httpClient, authMethod, newClientCredentials(authConfig), func() {
+		c.credentialsLock.Lock()
+		defer c.credentialsLock.Unlock()
+		c.clientConfig.Auth = authConfig
+		c.AuthMethod = authMethod
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info("Client credentials updated", zap.String("Authentication Method", authMethod))
+	return nil
+}
+
+// authMethod returns the authentication method currently configured for the client.
+func (c *Client) authMethod() string {
+	c.credentialsLock.RLock()
+	defer c.credentialsLock.RUnlock()
+	return c.AuthMethod
+}
+
+// cacheIdentity returns a hash of the authentication method and the principal the client authenticates as, which the
+// response cache keys responses by. Secrets and tokens are left out so that cached responses outlive token refreshes.
+func (c *Client) cacheIdentity() string {
+	c.credentialsLock.RLock()
+	defer c.credentialsLock.RUnlock()
+	auth := c.clientConfig.Auth
+	principal := strings.Join([]string{
+		c.AuthMethod,
+		auth.Username,
+		auth.ClientID,
+		auth.GitHubAppID,
+		strconv.FormatInt(auth.GitHubAppInstallationID, 10),
+		auth.GitHubAppInstallationOwner,
+		auth.GitHubAppInstallationRepository,
+	}, "\n")
+	identity := sha256.Sum256([]byte(principal))
+	return hex.EncodeToString(identity[:])
+}
+
+// newClientCredentials maps the authentication configuration onto the credentials used by the AuthTokenHandler.
+func newClientCredentials(authConfig AuthConfig) authenticationhandler.ClientCredentials {
+	return authenticationhandler.ClientCredentials{
+		Username:                        authConfig.Username,
+		Password:                        authConfig.Password,
+		ClientID:                        authConfig.ClientID,
+		ClientSecret:                    authConfig.ClientSecret,
+		GitHubAppID:                     authConfig.GitHubAppID,
+		GitHubAppPrivateKey:             authConfig.GitHubAppPrivateKey,
+		GitHubAppInstallationID:         authConfig.GitHubAppInstallationID,
+		GitHubAppInstallationOwner:      authConfig.GitHubAppInstallationOwner,
+		GitHubAppInstallationRepository: authConfig.GitHubAppInstallationRepository,
+		RefreshToken:                    authConfig.RefreshToken,
+	}
+}
+
+// loadGitHubAppPrivateKey reads the GitHub App private key from GitHubAppPrivateKeyPath when the key itself
+// has not been supplied, so that the key is held in memory for signing app JWTs.
+func loadGitHubAppPrivateKey(authConfig *AuthConfig) error {
+	if authConfig.GitHubAppPrivateKey != "" || authConfig.GitHubAppPrivateKeyPath == "" {
+		return nil
+	}
+
+	file, err := helpers.SafeOpenFile(authConfig.GitHubAppPrivateKeyPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	keyBytes, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("failed to read the github app private key: %s, error: %w", authConfig.GitHubAppPrivateKeyPath, err)
+	}
+
+	authConfig.GitHubAppPrivateKey = string(keyBytes)
+	return nil
+}
@@ -0,0 +1,75 @@
+// httpclient/credentials_test.go
+package httpclient
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUpdateCredentials tests that usable credentials replace the client's credentials and token together, and that
+// credentials the api handler does not support are rejected without touching the running client.
+func TestUpdateCredentials(t *testing.T) {
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	valid, err := client.AuthTokenHandler.CheckAndRefreshToken(client.APIHandler, client.httpClient, 0)
+	require.NoError(t, err)
+	require.True(t, valid)
+	token := client.AuthTokenHandler.GetToken()
+
+	// The test server's generic api handler only supports basicauth
+	err = client.UpdateCredentials(AuthConfig{ClientID: "0b2b4c6e-8f0a-4c2e-9a1b-3d5f7a9c1e2f", ClientSecret: "Secret0123456789abc"})
+	assert.ErrorContains(t, err, "not supported")
+	assert.Equal(t, "basicauth", client.authMethod())
+	assert.Equal(t, "user", client.clientConfig.Auth.Username)
+	assert.Equal(t, "user", client.AuthTokenHandler.Credentials.Username)
+	assert.Equal(t, token, client.AuthTokenHandler.GetToken())
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	err = client.UpdateCredentials(AuthConfig{GitHubAppID: "12345", GitHubAppPrivateKey: string(keyPEM), GitHubAppInstallationID: 1})
+	assert.ErrorContains(t, err, "not supported", "GitHub App credentials are only accepted by handlers supporting them")
+	assert.Equal(t, "basicauth", client.authMethod())
+
+	require.NoError(t, client.UpdateCredentials(AuthConfig{Username: "other", Password: "password456"}))
+	assert.Equal(t, "other", client.clientConfig.Auth.Username)
+	assert.Equal(t, "other", client.AuthTokenHandler.Credentials.Username)
+}
+
+// TestUpdateCredentialsCache tests that responses cached before the credentials are updated are not served afterwards.
+func TestUpdateCredentialsCache(t *testing.T) {
+	var calls int32
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "private, max-age=60")
+		fmt.Fprintf(w, `{"call":%d}`, n)
+	}, func(config *ClientConfig) {
+		config.ClientOptions.Cache.EnableCache = true
+	})
+
+	get := func() int {
+		var out struct {
+			Call int `json:"call"`
+		}
+		_, err := client.DoRequest(http.MethodGet, "/resources/1", nil, &out)
+		require.NoError(t, err)
+		return out.Call
+	}
+
+	assert.Equal(t, 1, get())
+	assert.Equal(t, 1, get(), "the fresh response is served from the cache")
+
+	require.NoError(t, client.UpdateCredentials(AuthConfig{Username: "other", Password: "password456"}))
+	assert.Equal(t, 2, get(), "the response cached for the previous credentials must not be served")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
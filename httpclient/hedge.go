@@ -0,0 +1,247 @@
+// httpclient/hedge.go
+package httpclient
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultHedgeBudget = 0.1 // Share of GET requests that may be hedged
+	hedgeBudgetBurst   = 10  // Hedges that may be sent at once after a quiet period
+	hedgeLatencyWindow = 100 // Recent GET latencies the learned hedge delay is calculated from
+	hedgeMinSamples    = 20  // Latencies needed before the learned hedge delay is used
+	hedgePercentile    = 0.95
+)
+
+// hedger decides when a slow GET request gets a second attempt. Hedges are paid for from a budget that every GET
+// request tops up by the budget ratio, so that hedging cannot multiply the load on a struggling server.
+type hedger struct {
+	delay time.Duration // Fixed hedge delay, zero to use the learned percentile
+	ratio float64       // Budget earned per request
+
+	mu        sync.Mutex
+	tokens    float64
+	latencies []time.Duration // Ring buffer of recent latencies
+	next      int
+}
+
+// newHedger creates a hedger for the configuration, or returns nil if hedging is disabled.
+func newHedger(config HedgingConfig) *hedger {
+	if !config.EnableHedging {
+		return nil
+	}
+	ratio := config.HedgeBudget
+	if ratio <= 0 {
+		ratio = DefaultHedgeBudget
+	}
+	return &hedger{delay: config.HedgeDelay, ratio: ratio, tokens: hedgeBudgetBurst}
+}
+
+// hedgeDelay returns how long to wait for a response before hedging. The second result is false until enough
+// latencies have been observed to learn the delay.
+func (h *hedger) hedgeDelay() (time.Duration, bool) {
+	if h.delay > 0 {
+		return h.delay, true
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.latencies) < hedgeMinSamples {
+		return 0, false
+	}
+	sorted := append([]time.Duration(nil), h.latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[int(math.Ceil(hedgePercentile*float64(len(sorted))))-1], true
+}
+
+// observe records the latency of a successful attempt.
+func (h *hedger) observe(latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.latencies) < hedgeLatencyWindow {
+		h.latencies = append(h.latencies, latency)
+		return
+	}
+	h.latencies[h.next] = latency
+	h.next = (h.next + 1) % hedgeLatencyWindow
+}
+
+// deposit tops the budget up for a request.
+func (h *hedger) deposit() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tokens = math.Min(hedgeBudgetBurst, h.tokens+h.ratio)
+}
+
+// spend takes a hedge from the budget, reporting false if the budget is used up.
+func (h *hedger) spend() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.tokens < 1 {
+		return false
+	}
+	h.tokens--
+	return true
+}
+
+// refund returns a hedge to the budget that was not sent.
+func (h *hedger) refund() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tokens = math.Min(hedgeBudgetBurst, h.tokens+1)
+}
+
+// hedgeResult is the outcome of one attempt of a hedged request.
+type hedgeResult struct {
+	index   int
+	resp    *http.Response
+	err     error
+	latency time.Duration
+}
+
+// doHedged sends req and, if no response has arrived after the hedge delay, a second attempt of it. The first
+// response wins and the other attempt is canceled; an attempt failing without a response waits for the other one.
+// The hedge takes a concurrency permit and a rate limit token of its own and is skipped when either is not available
+// right away or the hedge budget is used up.
+// The winning attempt's context is canceled when its response body is closed.
+func (c *Client) doHedged(req *http.Request, log logger.Logger, method, endpoint string) (*http.Response, error) {
+	h := c.hedger
+	h.deposit()
+
+	delay, ok := h.hedgeDelay()
+	if !ok {
+		start := time.Now()
+		resp, err := c.send(req, log, method, endpoint)
+		if err == nil {
+			h.observe(time.Since(start))
+		}
+		return resp, err
+	}
+
+	results := make(chan hedgeResult, 2)
+	var cancels []context.CancelFunc
+	send := func(attempt *http.Request) {
+		ctx, cancel := context.WithCancel(attempt.Context())
+		cancels = append(cancels, cancel)
+		index := len(cancels) - 1
+		go func() {
+			start := time.Now()
+			resp, err := c.send(attempt.WithContext(ctx), log, method, endpoint)
+			results <- hedgeResult{index: index, resp: resp, err: err, latency: time.Since(start)}
+		}()
+	}
+	send(req)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var hedgePermit func()
+	pending := 1
+	var failed hedgeResult
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			release, ok := c.acquireHedge(endpoint)
+			if !ok {
+				continue
+			}
+			hedge := req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					release()
+					continue
+				}
+				hedge.Body = body
+			}
+			log.Debug("Hedging slow request", zap.String("method", method), zap.String("endpoint", endpoint), zap.Duration("hedge_delay", delay))
+			hedgePermit = release
+			send(hedge)
+			pending++
+
+		case result := <-results:
+			pending--
+			if result.err != nil {
+				failed = result
+				continue
+			}
+
+			h.observe(result.latency)
+			if result.index > 0 {
+				log.Debug("Hedged request won", zap.String("method", method), zap.String("endpoint", endpoint), zap.Duration("latency", result.latency))
+			}
+			for i, cancel := range cancels {
+				if i != result.index {
+					cancel()
+				}
+			}
+			go drainHedges(results, pending, hedgePermit)
+			result.resp.Body = &cancelOnClose{ReadCloser: result.resp.Body, cancel: cancels[result.index]}
+			return result.resp, nil
+		}
+	}
+
+	// Every attempt failed without a response
+	for _, cancel := range cancels {
+		cancel()
+	}
+	if hedgePermit != nil {
+		hedgePermit()
+	}
+	return nil, failed.err
+}
+
+// acquireHedge takes a concurrency permit, a hedge from the budget and a rate limit token for endpoint, returning
+// the function releasing the permit.
+func (c *Client) acquireHedge(endpoint string) (func(), bool) {
+	requestID, ok := c.ConcurrencyHandler.TryAcquireConcurrencyPermit()
+	if !ok {
+		c.Logger.Debug("Skipping hedge, no concurrency permit is free")
+		return nil, false
+	}
+	if !c.hedger.spend() {
+		c.ConcurrencyHandler.ReleaseConcurrencyPermit(requestID)
+		c.Logger.Debug("Skipping hedge, hedge budget is used up")
+		return nil, false
+	}
+	if !c.RateLimiter.Allow(endpoint) {
+		c.hedger.refund()
+		c.ConcurrencyHandler.ReleaseConcurrencyPermit(requestID)
+		c.Logger.Debug("Skipping hedge, rate limit allows no request right now")
+		return nil, false
+	}
+	return func() { c.ConcurrencyHandler.ReleaseConcurrencyPermit(requestID) }, true
+}
+
+// drainHedges waits for the canceled attempts of a hedged request, closes any response they got and then releases
+// the hedge's concurrency permit.
+func drainHedges(results <-chan hedgeResult, pending int, release func()) {
+	for ; pending > 0; pending-- {
+		if result := <-results; result.resp != nil {
+			result.resp.Body.Close()
+		}
+	}
+	if release != nil {
+		release()
+	}
+}
+
+// cancelOnClose cancels the context of a request once its response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
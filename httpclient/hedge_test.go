@@ -0,0 +1,112 @@
+// httpclient/hedge_test.go
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExecuteHedgedRequest tests that a GET request slower than the hedge delay is answered by the hedge and that the
+// slow attempt is canceled.
+func TestExecuteHedgedRequest(t *testing.T) {
+	var calls int32
+	canceled := make(chan struct{})
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// Read the body so that the server notices the client going away
+		io.Copy(io.Discard, r.Body)
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+				close(canceled)
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"device"}`))
+	}, func(config *ClientConfig) {
+		config.ClientOptions.Concurrency.MaxConcurrentRequests = 2
+		config.ClientOptions.Hedging = HedgingConfig{EnableHedging: true, HedgeDelay: 50 * time.Millisecond}
+	})
+
+	var out struct {
+		Name string `json:"name"`
+	}
+	start := time.Now()
+	resp, err := client.DoRequest(http.MethodGet, "/resources/1", nil, &out)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "device", out.Name)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	select {
+	case <-canceled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("slow attempt was not canceled")
+	}
+}
+
+// TestExecuteHedgedRequestRateLimited tests that no hedge is sent when the client side rate limit has no token left.
+func TestExecuteHedgedRequestRateLimited(t *testing.T) {
+	var calls int32
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"device"}`))
+	}, func(config *ClientConfig) {
+		config.ClientOptions.Concurrency.MaxConcurrentRequests = 2
+		config.ClientOptions.Hedging = HedgingConfig{EnableHedging: true, HedgeDelay: 20 * time.Millisecond}
+		config.ClientOptions.RateLimit = RateLimitConfig{RequestsPerSecond: 1, Burst: 1}
+	})
+
+	var out struct {
+		Name string `json:"name"`
+	}
+	resp, err := client.DoRequest(http.MethodGet, "/resources/1", nil, &out)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "device", out.Name)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+// TestHedgerDelay tests the fixed and learned hedge delays.
+func TestHedgerDelay(t *testing.T) {
+	assert.Nil(t, newHedger(HedgingConfig{}))
+
+	fixed := newHedger(HedgingConfig{EnableHedging: true, HedgeDelay: time.Second})
+	delay, ok := fixed.hedgeDelay()
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, delay)
+
+	learned := newHedger(HedgingConfig{EnableHedging: true})
+	_, ok = learned.hedgeDelay()
+	assert.False(t, ok, "no delay before enough latencies are observed")
+	for i := 1; i <= 2*hedgeLatencyWindow; i++ {
+		learned.observe(time.Duration(i%hedgeLatencyWindow) * time.Millisecond)
+	}
+	delay, ok = learned.hedgeDelay()
+	assert.True(t, ok)
+	assert.Equal(t, 94*time.Millisecond, delay)
+}
+
+// TestHedgerBudget tests that hedges are limited to the budget earned by requests.
+func TestHedgerBudget(t *testing.T) {
+	h := newHedger(HedgingConfig{EnableHedging: true, HedgeBudget: 0.5})
+	for i := 0; i < hedgeBudgetBurst; i++ {
+		assert.True(t, h.spend())
+	}
+	assert.False(t, h.spend())
+
+	h.deposit()
+	assert.False(t, h.spend())
+	h.deposit()
+	assert.True(t, h.spend())
+}
@@ -3,9 +3,10 @@ package httpclient
 
 import (
 	"bytes"
+	"fmt"
 	"net/http"
 
-	"github.com/deploymenttheory/go-api-http-client/authenticationhandler"
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
 	"github.com/deploymenttheory/go-api-http-client/headers"
 	"github.com/deploymenttheory/go-api-http-client/response"
 )
@@ -41,17 +42,13 @@ func (c *Client) DoMultipartRequest(method, endpoint string, fields map[string]s
 	log := c.Logger
 
 	// Auth Token validation check
-	clientCredentials := authenticationhandler.ClientCredentials{
-		Username:     c.clientConfig.Auth.Username,
-		Password:     c.clientConfig.Auth.Password,
-		ClientID:     c.clientConfig.Auth.ClientID,
-		ClientSecret: c.clientConfig.Auth.ClientSecret,
-	}
-
-	valid, err := c.AuthTokenHandler.CheckAndRefreshAuthToken(c.APIHandler, c.httpClient, clientCredentials, c.clientConfig.ClientOptions.Timeout.TokenRefreshBufferPeriod)
-	if err != nil || !valid {
+	valid, err := c.AuthTokenHandler.CheckAndRefreshToken(c.APIHandler, c.httpClient, c.clientConfig.ClientOptions.Timeout.TokenRefreshBufferPeriod)
+	if err != nil {
 		return nil, err
 	}
+	if !valid {
+		return nil, fmt.Errorf("%w: no valid authentication token", apierrors.ErrUnauthorized)
+	}
 
 	// Marshal the multipart form data
 	requestData, contentType, err := c.APIHandler.MarshalMultipartRequest(fields, files, log)
@@ -77,8 +74,8 @@ func (c *Client) DoMultipartRequest(method, endpoint string, fields map[string]s
 	headerHandler.SetRequestHeaders(endpoint)
 	headerHandler.LogHeaders(c.clientConfig.ClientOptions.Logging.HideSensitiveData)
 
-	// Execute the request
-	resp, err := c.do(req, log, method, endpoint)
+	// Execute the request, adjusting concurrency based on its outcome
+	resp, err := c.send(req, log, method, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +84,7 @@ func (c *Client) DoMultipartRequest(method, endpoint string, fields map[string]s
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		// Handle error responses
 		//return nil, c.handleErrorResponse(resp, log, "Failed to process the HTTP request", method, endpoint)
-		return nil, response.HandleAPIErrorResponse(resp, log)
+		return nil, c.handleAPIErrorResponse(resp, log)
 	} else {
 		// Handle successful responses
 		return resp, response.HandleAPISuccessResponse(resp, out, log)
@@ -4,10 +4,13 @@ package httpclient
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
-	"github.com/deploymenttheory/go-api-http-client/authenticationhandler"
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/apihandler"
+	"github.com/deploymenttheory/go-api-http-client/concurrency"
 	"github.com/deploymenttheory/go-api-http-client/headers"
 	"github.com/deploymenttheory/go-api-http-client/httpmethod"
 	"github.com/deploymenttheory/go-api-http-client/logger"
@@ -63,14 +66,44 @@ import (
 //   within the client's concurrency model.
 // - The decision to retry requests is based on the idempotency of the HTTP method and the client's retry configuration,
 //   including maximum retry attempts and total retry duration.
+// - When request coalescing is enabled, concurrent identical GET requests share a single request to the server and each
+//   caller decodes its own copy of the response into out.
 
 func (c *Client) DoRequest(method, endpoint string, body, out interface{}) (*http.Response, error) {
+	return c.DoRequestWithOptions(method, endpoint, body, out, RequestOptions{})
+}
+
+// RequestOptions overrides client settings for a single request.
+type RequestOptions struct {
+	RetryPolicy ratehandler.RetryPolicy // Retry policy used instead of the client's, for idempotent methods only
+	Weight      int                     // Concurrency permits the request counts as, for expensive calls; defaults to 1
+	Priority    concurrency.Priority    // Priority while waiting for a concurrency permit; normal by default
+	Caller      string                  // Tenant or caller the request is made for; waiting requests are served round robin across callers
+	APIVersion  string                  // API version used instead of the api handler's, e.g. "beta" for Microsoft Graph
+}
+
+// permitRequest returns the concurrency permit the request waits for.
+func (o RequestOptions) permitRequest() concurrency.PermitRequest {
+	return concurrency.PermitRequest{Weight: o.Weight, Priority: o.Priority, Caller: o.Caller}
+}
+
+// coalescable reports whether the request may share a response with identical requests, which only holds when it
+// has no options that change how it is sent.
+func (o RequestOptions) coalescable() bool {
+	return o.RetryPolicy == nil && o.Weight <= 1 && o.Priority == concurrency.PriorityNormal && o.Caller == "" && o.APIVersion == ""
+}
+
+// DoRequestWithOptions executes a request like DoRequest, applying the per-request options. Requests with options
+// are never coalesced with other requests.
+func (c *Client) DoRequestWithOptions(method, endpoint string, body, out interface{}, options RequestOptions) (*http.Response, error) {
 	log := c.Logger
 
-	if httpmethod.IsIdempotentHTTPMethod(method) {
-		return c.executeRequestWithRetries(method, endpoint, body, out)
+	if method == http.MethodGet && c.clientConfig.ClientOptions.Concurrency.EnableRequestCoalescing && options.coalescable() {
+		return c.executeCoalescedRequest(method, endpoint, out)
+	} else if httpmethod.IsIdempotentHTTPMethod(method) {
+		return c.executeRequestWithRetries(method, endpoint, body, out, options)
 	} else if httpmethod.IsNonIdempotentHTTPMethod(method) {
-		return c.executeRequest(method, endpoint, body, out)
+		return c.executeRequest(method, endpoint, body, out, options)
 	} else {
 		return nil, log.Error("HTTP method not supported", zap.String("method", method))
 	}
@@ -79,8 +112,8 @@ func (c *Client) DoRequest(method, endpoint string, body, out interface{}) (*htt
 // executeRequestWithRetries executes an HTTP request using the specified method, endpoint, request body, and output variable.
 // It is designed for idempotent HTTP methods (GET, PUT, DELETE), where the request can be safely retried in case of
 // transient errors or rate limiting. The function implements a retry mechanism that respects the client's configuration
-// for maximum retry attempts and total retry duration. Each retry attempt uses exponential backoff with jitter to avoid
-// thundering herd problems. An instance of a logger (conforming to the logger.Logger interface) is used for logging the
+// for maximum retry attempts and total retry duration. The retry policy decides which failed attempts are retried and
+// how long to wait before each retry, exponential backoff with jitter by default. An instance of a logger (conforming to the logger.Logger interface) is used for logging the
 // request, retry attempts, and any errors encountered.
 //
 // Parameters:
@@ -91,7 +124,8 @@ func (c *Client) DoRequest(method, endpoint string, body, out interface{}) (*htt
 // methods that do not send a payload.
 // - out: A pointer to the variable where the unmarshaled response will be stored. The function expects this to be a
 // pointer to a struct that matches the expected response schema.
-// - log:
+// - options: Per-request options; a retry policy set here replaces the client's, the API version replaces the api
+// handler's, and the weight, priority and caller apply to the concurrency permit.
 //
 // Returns:
 // - *http.Response: The HTTP response from the server, which may be the response from a successful request or the last
@@ -108,30 +142,41 @@ func (c *Client) DoRequest(method, endpoint string, body, out interface{}) (*htt
 // - The caller is responsible for closing the response body to prevent resource leaks.
 // - The function respects the client's concurrency token, acquiring and releasing it as needed to ensure safe concurrent
 // operations.
-// - The retry mechanism employs exponential backoff with jitter to mitigate the impact of retries on the server.
-func (c *Client) executeRequestWithRetries(method, endpoint string, body, out interface{}) (*http.Response, error) {
+// - Waits requested by the server with Retry-After take precedence over the policy's backoff and do not count as retries.
+func (c *Client) executeRequestWithRetries(method, endpoint string, body, out interface{}, options RequestOptions) (*http.Response, error) {
 	log := c.Logger
 
+	policy := options.RetryPolicy
+	if policy == nil {
+		policy = c.RetryPolicy
+	}
+	if policy == nil {
+		policy = ratehandler.DefaultRetryPolicy()
+	}
+
 	// Include the core logic for handling non-idempotent requests with retries here.
 	log.Debug("Executing request with retries", zap.String("method", method), zap.String("endpoint", endpoint))
 
 	// Auth Token validation check
-	clientCredentials := authenticationhandler.ClientCredentials{
-		Username:     c.clientConfig.Auth.Username,
-		Password:     c.clientConfig.Auth.Password,
-		ClientID:     c.clientConfig.Auth.ClientID,
-		ClientSecret: c.clientConfig.Auth.ClientSecret,
+	valid, err := c.AuthTokenHandler.CheckAndRefreshToken(c.APIHandler, c.httpClient, c.clientConfig.ClientOptions.Timeout.TokenRefreshBufferPeriod)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, fmt.Errorf("%w: no valid authentication token", apierrors.ErrUnauthorized)
 	}
 
-	valid, err := c.AuthTokenHandler.CheckAndRefreshAuthToken(c.APIHandler, c.httpClient, clientCredentials, c.clientConfig.ClientOptions.Timeout.TokenRefreshBufferPeriod)
-	if err != nil || !valid {
+	// Wait for the rate limits before taking a concurrency permit
+	if err := c.waitForRateLimits(context.Background(), endpoint); err != nil {
+		log.Error("Failed waiting for rate limits", zap.String("endpoint", endpoint), zap.Error(err))
 		return nil, err
 	}
 
 	// Acquire a concurrency permit along with a unique request ID
-	ctx, requestID, err := c.ConcurrencyHandler.AcquireConcurrencyPermit(context.Background())
+	ctx, requestID, err := c.ConcurrencyHandler.AcquirePermit(context.Background(), options.permitRequest())
 	if err != nil {
-		return nil, c.Logger.Error("Failed to acquire concurrency permit", zap.Error(err))
+		c.Logger.Error("Failed to acquire concurrency permit", zap.Error(err))
+		return nil, fmt.Errorf("failed to acquire concurrency permit: %w", err)
 	}
 
 	// Ensure the permit is released after the function exits
@@ -146,7 +191,10 @@ func (c *Client) executeRequestWithRetries(method, endpoint string, body, out in
 	}
 
 	// Construct URL with correct structure defined in api handler
-	url := c.APIHandler.ConstructAPIResourceEndpoint(endpoint, log)
+	url, err := c.resourceURL(endpoint, options)
+	if err != nil {
+		return nil, err
+	}
 
 	// Increment total request counter within ConcurrencyHandler's metrics
 	c.ConcurrencyHandler.Metrics.Lock.Lock()
@@ -169,21 +217,44 @@ func (c *Client) executeRequestWithRetries(method, endpoint string, body, out in
 
 	// Define a retry deadline based on the client's total retry duration configuration
 	totalRetryDeadline := time.Now().Add(c.clientConfig.ClientOptions.Timeout.TotalRetryDuration)
+	retryCtx, cancelRetries := context.WithDeadline(ctx, totalRetryDeadline)
+	defer cancelRetries()
 
 	var resp *http.Response
 	var retryCount int
+	var previousWait time.Duration   // Backoff before the previous retry, used by decorrelated jitter
+	var attempts []apierrors.Attempt // Attempt history reported if every attempt fails
+
 	for time.Now().Before(totalRetryDeadline) { // Check if the current time is before the total retry deadline
 		req = req.WithContext(ctx)
 
+		// Rewind the request body consumed by the previous attempt
+		if len(attempts) > 0 && req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+
 		// Log outgoing cookies
 		log.LogCookies("outgoing", req, method, endpoint)
 
-		// Execute the HTTP request
-		resp, err = c.do(req, log, method, endpoint)
+		// Execute the HTTP request, hedging slow GET requests when enabled
+		if c.hedger != nil && method == http.MethodGet {
+			resp, err = c.doHedged(req, log, method, endpoint)
+		} else {
+			resp, err = c.send(req, log, method, endpoint)
+		}
 
 		// Log outgoing cookies
 		log.LogCookies("incoming", req, method, endpoint)
 
+		// Record the attempt
+		attempt := apierrors.Attempt{Number: len(attempts) + 1, Err: err, Class: apierrors.ClassifyTransportError(err)}
+		if resp != nil {
+			attempt.StatusCode = resp.StatusCode
+		}
+		attempts = append(attempts, attempt)
+
 		// Check for successful status code
 		if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 400 {
 			if resp.StatusCode >= 300 {
@@ -193,54 +264,71 @@ func (c *Client) executeRequestWithRetries(method, endpoint string, body, out in
 			return resp, response.HandleAPISuccessResponse(resp, out, log)
 		}
 
-		// Leverage TranslateStatusCode for more descriptive error logging
-		statusMessage := status.TranslateStatusCode(resp)
-
-		// Check for non-retryable errors
-		if resp != nil && status.IsNonRetryableStatusCode(resp) {
-			log.Warn("Non-retryable error received", zap.Int("status_code", resp.StatusCode), zap.String("status_message", statusMessage))
-			return resp, response.HandleAPIErrorResponse(resp, log)
+		// Handle errors without a response
+		if err != nil && !policy.ShouldRetry(req, nil, err) {
+			log.Warn("Non-retryable transport error", zap.String("method", method), zap.String("endpoint", endpoint), zap.String("error_class", string(attempt.Class)), zap.Error(err))
+			return nil, err
 		}
 
-		// Parsing rate limit headers if a rate-limit error is detected
-		if status.IsRateLimitError(resp) {
-			waitDuration := ratehandler.ParseRateLimitHeaders(resp, log)
-			if waitDuration > 0 {
-				log.Warn("Rate limit encountered, waiting before retrying", zap.Duration("waitDuration", waitDuration))
-				time.Sleep(waitDuration)
-				continue // Continue to next iteration after waiting
+		var waitDuration time.Duration
+		if err == nil {
+			// Leverage TranslateStatusCode for more descriptive error logging
+			statusMessage := status.TranslateStatusCode(resp)
+
+			// Return responses the policy does not retry
+			if !policy.ShouldRetry(req, resp, nil) {
+				log.Warn("Non-retryable error received", zap.Int("status_code", resp.StatusCode), zap.String("status_message", statusMessage))
+				return resp, c.handleAPIErrorResponse(resp, log)
+			}
+
+			// Parsing rate limit headers if a rate-limit error is detected
+			if status.IsRateLimitError(resp) {
+				waitDuration = ratehandler.ParseRateLimitHeaders(resp, log)
+				if waitDuration > 0 {
+					log.Warn("Rate limit encountered, waiting before retrying", zap.Duration("waitDuration", waitDuration))
+				}
 			}
 		}
 
-		// Handling retryable errors with exponential backoff
-		if status.IsTransientError(resp) {
+		// Back off as the policy decides unless the server asked for a wait
+		if waitDuration <= 0 {
 			retryCount++
 			if retryCount > c.clientConfig.ClientOptions.Retry.MaxRetryAttempts {
 				log.Warn("Max retry attempts reached", zap.String("method", method), zap.String("endpoint", endpoint))
 				break // Stop retrying if max attempts are reached
 			}
-			waitDuration := ratehandler.CalculateBackoff(retryCount)
-			log.Warn("Retrying request due to transient error", zap.String("method", method), zap.String("endpoint", endpoint), zap.Int("retryCount", retryCount), zap.Duration("waitDuration", waitDuration), zap.Error(err))
-			time.Sleep(waitDuration) // Wait before retrying
-			continue                 // Continue to next iteration after waiting
+			waitDuration = policy.Backoff(retryCount, previousWait)
+			previousWait = waitDuration
+			log.Warn("Retrying request", zap.String("method", method), zap.String("endpoint", endpoint), zap.Int("retryCount", retryCount), zap.Duration("waitDuration", waitDuration), zap.String("error_class", string(attempt.Class)), zap.Error(err))
 		}
 
-		// Handle error responses
-		if err != nil || !status.IsRetryableStatusCode(resp.StatusCode) {
-			if apiErr := response.HandleAPIErrorResponse(resp, log); apiErr != nil {
-				err = apiErr
-			}
-			log.LogError("request_error", method, endpoint, resp.StatusCode, resp.Status, err, status.TranslateStatusCode(resp))
+		if time.Now().Add(waitDuration).After(totalRetryDeadline) {
+			log.Warn("Retry wait exceeds the total retry duration", zap.String("method", method), zap.String("endpoint", endpoint), zap.Duration("waitDuration", waitDuration))
 			break
 		}
-	}
+		if resp != nil {
+			// Release the connection of the discarded response
+			resp.Body.Close()
+		}
+		attempts[len(attempts)-1].Wait = waitDuration
+		time.Sleep(waitDuration) // Wait before retrying
 
-	// Handles final non-API error.
-	if err != nil {
-		return nil, err
+		// Each retry is a request of its own to the rate limits
+		if err = c.waitForRateLimits(retryCtx, endpoint); err != nil {
+			log.Warn("Rate limit wait exceeds the total retry duration", zap.String("method", method), zap.String("endpoint", endpoint), zap.Error(err))
+			resp = nil
+			break
+		}
 	}
 
-	return resp, response.HandleAPIErrorResponse(resp, log)
+	// Every attempt allowed by the retry configuration failed
+	if resp == nil {
+		if err == nil {
+			err = context.DeadlineExceeded
+		}
+		return nil, &apierrors.RetriesExhaustedError{Attempts: attempts, Err: err}
+	}
+	return resp, &apierrors.RetriesExhaustedError{Attempts: attempts, Err: c.handleAPIErrorResponse(resp, log)}
 }
 
 // executeRequest executes an HTTP request using the specified method, endpoint, and request body without implementing
@@ -256,8 +344,8 @@ func (c *Client) executeRequestWithRetries(method, endpoint string, body, out in
 //   - out: A pointer to the variable where the unmarshaled response will be stored. This should be a pointer to a struct
 //
 // that matches the expected response schema.
-// - log: An instance of a logger (conforming to the logger.Logger interface) used for logging the request and any errors
-// encountered.
+// - options: Per-request options; the API version replaces the api handler's, and the weight, priority and caller
+// apply to the concurrency permit.
 //
 // Returns:
 // - *http.Response: The HTTP response from the server. This includes the status code, headers, and body of the response.
@@ -275,29 +363,32 @@ func (c *Client) executeRequestWithRetries(method, endpoint string, body, out in
 // execution.
 // - The function logs detailed information about the request execution, including the method, endpoint, status code, and
 // any errors encountered.
-func (c *Client) executeRequest(method, endpoint string, body, out interface{}) (*http.Response, error) {
+func (c *Client) executeRequest(method, endpoint string, body, out interface{}, options RequestOptions) (*http.Response, error) {
 	log := c.Logger
 
 	// Include the core logic for handling idempotent requests here.
 	log.Debug("Executing request without retries", zap.String("method", method), zap.String("endpoint", endpoint))
 
 	// Auth Token validation check
-	clientCredentials := authenticationhandler.ClientCredentials{
-		Username:     c.clientConfig.Auth.Username,
-		Password:     c.clientConfig.Auth.Password,
-		ClientID:     c.clientConfig.Auth.ClientID,
-		ClientSecret: c.clientConfig.Auth.ClientSecret,
+	valid, err := c.AuthTokenHandler.CheckAndRefreshToken(c.APIHandler, c.httpClient, c.clientConfig.ClientOptions.Timeout.TokenRefreshBufferPeriod)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, fmt.Errorf("%w: no valid authentication token", apierrors.ErrUnauthorized)
 	}
 
-	valid, err := c.AuthTokenHandler.CheckAndRefreshAuthToken(c.APIHandler, c.httpClient, clientCredentials, c.clientConfig.ClientOptions.Timeout.TokenRefreshBufferPeriod)
-	if err != nil || !valid {
+	// Wait for the rate limits before taking a concurrency permit
+	if err := c.waitForRateLimits(context.Background(), endpoint); err != nil {
+		log.Error("Failed waiting for rate limits", zap.String("endpoint", endpoint), zap.Error(err))
 		return nil, err
 	}
 
 	// Acquire a concurrency permit along with a unique request ID
-	ctx, requestID, err := c.ConcurrencyHandler.AcquireConcurrencyPermit(context.Background())
+	ctx, requestID, err := c.ConcurrencyHandler.AcquirePermit(context.Background(), options.permitRequest())
 	if err != nil {
-		return nil, c.Logger.Error("Failed to acquire concurrency permit", zap.Error(err))
+		c.Logger.Error("Failed to acquire concurrency permit", zap.Error(err))
+		return nil, fmt.Errorf("failed to acquire concurrency permit: %w", err)
 	}
 
 	// Ensure the permit is released after the function exits
@@ -314,8 +405,11 @@ func (c *Client) executeRequest(method, endpoint string, body, out interface{})
 		return nil, err
 	}
 
-	// Construct URL using the ConstructAPIResourceEndpoint function
-	url := c.APIHandler.ConstructAPIResourceEndpoint(endpoint, log)
+	// Construct URL with correct structure defined in api handler
+	url, err := c.resourceURL(endpoint, options)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create a new HTTP request with the provided method, URL, and body
 	req, err := http.NewRequest(method, url, bytes.NewBuffer(requestData))
@@ -336,21 +430,12 @@ func (c *Client) executeRequest(method, endpoint string, body, out interface{})
 	// Log outgoing cookies
 	log.LogCookies("outgoing", req, method, endpoint)
 
-	// Measure the time taken to execute the request and receive the response
-	startTime := time.Now()
-
-	// Execute the HTTP request
-	resp, err := c.do(req, log, method, endpoint)
+	// Execute the HTTP request, adjusting concurrency based on its outcome
+	resp, err := c.send(req, log, method, endpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate the duration between sending the request and receiving the response
-	duration := time.Since(startTime)
-
-	// Evaluate and adjust concurrency based on the request's feedback
-	c.ConcurrencyHandler.EvaluateAndAdjustConcurrency(resp, duration)
-
 	// Log outgoing cookies
 	log.LogCookies("incoming", req, method, endpoint)
 
@@ -368,7 +453,7 @@ func (c *Client) executeRequest(method, endpoint string, body, out interface{})
 	}
 
 	// Handle error responses for status codes outside the successful range
-	return nil, response.HandleAPIErrorResponse(resp, log)
+	return nil, c.handleAPIErrorResponse(resp, log)
 }
 
 // do sends an HTTP request using the client's HTTP client. It logs the request and error details, if any,
@@ -394,7 +479,7 @@ func (c *Client) do(req *http.Request, log logger.Logger, method, endpoint strin
 
 	if err != nil {
 		// Log the error with structured logging, including method, endpoint, and the error itself
-		log.Error("Failed to send request", zap.String("method", method), zap.String("endpoint", endpoint), zap.Error(err))
+		log.Error("Failed to send request", zap.String("method", method), zap.String("endpoint", endpoint), zap.String("error_class", string(apierrors.ClassifyTransportError(err))), zap.Error(err))
 		return nil, err
 	}
 
@@ -403,3 +488,54 @@ func (c *Client) do(req *http.Request, log logger.Logger, method, endpoint strin
 
 	return resp, nil
 }
+
+// resourceURL constructs the URL of an endpoint with the api handler, in the API version selected by the request
+// options if any. Handlers that serve a single version reject a selected version.
+func (c *Client) resourceURL(endpoint string, options RequestOptions) (string, error) {
+	if options.APIVersion == "" {
+		return c.APIHandler.ConstructAPIResourceEndpoint(endpoint, c.Logger), nil
+	}
+	selector, ok := c.APIHandler.(apihandler.APIVersionSelector)
+	if !ok {
+		return "", fmt.Errorf("api handler does not support selecting api version %q per request", options.APIVersion)
+	}
+	return selector.ConstructVersionedAPIResourceEndpoint(endpoint, options.APIVersion, c.Logger)
+}
+
+// send sends one attempt of a request and feeds its outcome to the concurrency handler. A response is evaluated
+// with the latency of the attempt; a transient transport error, such as a timeout or a reset connection, counts as
+// overload. Canceled attempts, such as the losers of hedged requests, are ignored.
+func (c *Client) send(req *http.Request, log logger.Logger, method, endpoint string) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.do(req, log, method, endpoint)
+	latency := time.Since(start)
+
+	switch {
+	case err == nil:
+		c.ConcurrencyHandler.EvaluateAndAdjustConcurrency(resp, latency)
+	case apierrors.ClassifyTransportError(err).Retryable():
+		c.ConcurrencyHandler.ScaleDown()
+	}
+	return resp, err
+}
+
+// waitForRateLimits waits for the client side rate limiter and for the pacer learning the server's rate limit budgets.
+func (c *Client) waitForRateLimits(ctx context.Context, endpoint string) error {
+	if err := c.RateLimiter.Wait(ctx, endpoint); err != nil {
+		return err
+	}
+	if c.Pacer == nil {
+		return nil
+	}
+	return c.Pacer.Wait(ctx, c.APIHandler.ConstructAPIResourceEndpoint(endpoint, c.Logger))
+}
+
+// handleAPIErrorResponse parses an error response. API handlers implementing apihandler.ErrorResponseParser
+// refine the result with the details of their API's error format.
+func (c *Client) handleAPIErrorResponse(resp *http.Response, log logger.Logger) error {
+	var parser response.ErrorParser
+	if errorParser, ok := c.APIHandler.(apihandler.ErrorResponseParser); ok {
+		parser = errorParser.ParseErrorResponse
+	}
+	return response.HandleAPIErrorResponseWithParser(resp, log, parser)
+}
@@ -0,0 +1,354 @@
+// httpclient/request_test.go
+package httpclient
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
+	"github.com/deploymenttheory/go-api-http-client/concurrency"
+	"github.com/deploymenttheory/go-api-http-client/ratehandler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServerClient builds a client for the generic api handler against a test server that issues bearer tokens
+// from /auth/token and serves every other path with handler. The configuration can be adjusted with configure.
+func newTestServerClient(t *testing.T, handler http.HandlerFunc, configure ...func(*ClientConfig)) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"token": "test-token", "expires": time.Now().Add(time.Hour)})
+	})
+	mux.HandleFunc("/", handler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	options, err := json.Marshal(map[string]interface{}{
+		"base_url":              server.URL,
+		"bearer_token_endpoint": "/auth/token",
+		"auth_modes":            []string{"basicauth"},
+	})
+	require.NoError(t, err)
+
+	config := ClientConfig{
+		Auth:        AuthConfig{Username: "user", Password: "password123"},
+		Environment: EnvironmentConfig{APIType: "generic", APIOptions: options},
+		ClientOptions: ClientOptions{
+			Logging:     LoggingConfig{LogLevel: "LogLevelError"},
+			Retry:       RetryConfig{MaxRetryAttempts: 2},
+			Concurrency: ConcurrencyConfig{MaxConcurrentRequests: 1},
+			Timeout:     TimeoutConfig{CustomTimeout: 5 * time.Second, TokenRefreshBufferPeriod: time.Minute, TotalRetryDuration: 30 * time.Second},
+		},
+	}
+	for _, fn := range configure {
+		fn(&config)
+	}
+
+	client, err := BuildClient(config)
+	require.NoError(t, err)
+	return client
+}
+
+// TestExecuteRequestRetriesExhausted tests that persistent transient failures return the attempt history.
+func TestExecuteRequestRetriesExhausted(t *testing.T) {
+	var calls int32
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	_, err := client.DoRequest(http.MethodGet, "/resources", nil, nil)
+	require.Error(t, err)
+
+	assert.ErrorIs(t, err, apierrors.ErrRetriesExhausted)
+	assert.ErrorIs(t, err, apierrors.ErrTransient)
+
+	var exhausted *apierrors.RetriesExhaustedError
+	require.ErrorAs(t, err, &exhausted)
+	assert.Len(t, exhausted.Attempts, 3)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	for _, attempt := range exhausted.Attempts {
+		assert.Equal(t, http.StatusServiceUnavailable, attempt.StatusCode)
+	}
+}
+
+// TestExecuteRequestNotFound tests that non-retryable failures return immediately and match their sentinel.
+func TestExecuteRequestNotFound(t *testing.T) {
+	var calls int32
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "missing", http.StatusNotFound)
+	})
+
+	_, err := client.DoRequest(http.MethodGet, "/resources/1", nil, nil)
+	require.Error(t, err)
+
+	assert.ErrorIs(t, err, apierrors.ErrNotFound)
+	assert.NotErrorIs(t, err, apierrors.ErrRetriesExhausted)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+// TestExecuteRequestCacheRevalidation tests that a 304 for a cached response decodes the cached body into out.
+func TestExecuteRequestCacheRevalidation(t *testing.T) {
+	var calls int32
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name":"device"}`))
+	}, func(config *ClientConfig) {
+		config.ClientOptions.Cache.EnableCache = true
+	})
+
+	for i := 0; i < 2; i++ {
+		var out struct {
+			Name string `json:"name"`
+		}
+		resp, err := client.DoRequest(http.MethodGet, "/resources/1", nil, &out)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "device", out.Name)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+// TestExecuteRequestCircuitOpen tests that requests fail fast once the circuit breaker for the host opens.
+func TestExecuteRequestCircuitOpen(t *testing.T) {
+	var calls int32
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}, func(config *ClientConfig) {
+		config.ClientOptions.CircuitBreaker = CircuitBreakerConfig{EnableCircuitBreaker: true, FailureThreshold: 2, CoolDown: time.Minute}
+	})
+
+	_, err := client.DoRequest(http.MethodGet, "/resources", nil, nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, apierrors.ErrCircuitOpen)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	_, err = client.DoRequest(http.MethodGet, "/resources", nil, nil)
+	var openErr *apierrors.CircuitOpenError
+	require.ErrorAs(t, err, &openErr)
+	assert.Greater(t, openErr.RetryAfter, 50*time.Second)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+// TestExecuteRequestRetryPolicy tests that 429 responses without Retry-After are retried with the configured backoff
+// and that a per-request policy replaces the client's.
+func TestExecuteRequestRetryPolicy(t *testing.T) {
+	var calls int32
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	}, func(config *ClientConfig) {
+		config.ClientOptions.Retry.BackoffStrategy = "constant"
+		config.ClientOptions.Retry.BaseDelay = 10 * time.Millisecond
+	})
+
+	_, err := client.DoRequest(http.MethodGet, "/resources", nil, nil)
+	var exhausted *apierrors.RetriesExhaustedError
+	require.ErrorAs(t, err, &exhausted)
+	assert.ErrorIs(t, err, apierrors.ErrRateLimited)
+	require.Len(t, exhausted.Attempts, 3)
+	assert.Equal(t, 10*time.Millisecond, exhausted.Attempts[0].Wait)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&calls, 0)
+	noRetry := &ratehandler.Policy{Retryable: func(req *http.Request, resp *http.Response, err error) bool { return false }}
+	_, err = client.DoRequestWithOptions(http.MethodGet, "/resources", nil, nil, RequestOptions{RetryPolicy: noRetry})
+	assert.ErrorIs(t, err, apierrors.ErrRateLimited)
+	assert.NotErrorIs(t, err, apierrors.ErrRetriesExhausted)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+// TestExecuteRequestTransportErrorRetried tests that connections dropped by the server are retried and that every
+// attempt records the error class.
+func TestExecuteRequestTransportErrorRetried(t *testing.T) {
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}, func(config *ClientConfig) {
+		config.ClientOptions.Retry.BaseDelay = 10 * time.Millisecond
+	})
+
+	_, err := client.DoRequest(http.MethodGet, "/resources", nil, nil)
+	var exhausted *apierrors.RetriesExhaustedError
+	require.ErrorAs(t, err, &exhausted)
+	assert.ErrorIs(t, err, io.EOF)
+	require.Len(t, exhausted.Attempts, 3)
+	for _, attempt := range exhausted.Attempts {
+		assert.Equal(t, apierrors.TransportErrorUnexpectedEOF, attempt.Class)
+		assert.Zero(t, attempt.StatusCode)
+	}
+}
+
+// TestExecuteRequestRetriesRateLimited tests that every retry waits for a token of the client side rate limit.
+func TestExecuteRequestRetriesRateLimited(t *testing.T) {
+	var calls int32
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}, func(config *ClientConfig) {
+		config.ClientOptions.Retry.BackoffStrategy = "constant"
+		config.ClientOptions.Retry.BaseDelay = time.Millisecond
+		config.ClientOptions.RateLimit = RateLimitConfig{RequestsPerSecond: 10, Burst: 1}
+	})
+
+	start := time.Now()
+	_, err := client.DoRequest(http.MethodGet, "/resources", nil, nil)
+	assert.ErrorIs(t, err, apierrors.ErrRetriesExhausted)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.GreaterOrEqual(t, time.Since(start), 180*time.Millisecond, "two retries take two tokens at 10 per second")
+}
+
+// TestExecuteRequestShedWhenQueueFull tests that requests arriving at a full permit queue fail with ErrOverloaded.
+func TestExecuteRequestShedWhenQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}, func(config *ClientConfig) {
+		config.ClientOptions.Concurrency.MaxQueueDepth = 1
+	})
+
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			var out map[string]interface{}
+			_, err := client.DoRequest(http.MethodGet, "/resources", nil, &out)
+			done <- err
+		}()
+	}
+	// One request holds the only permit and the other fills the queue
+	for client.ConcurrencyHandler.InUse() < 1 || client.ConcurrencyHandler.Waiting() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	_, err := client.DoRequestWithOptions(http.MethodGet, "/resources", nil, nil, RequestOptions{Priority: concurrency.PriorityBackground})
+	assert.ErrorIs(t, err, apierrors.ErrOverloaded)
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, <-done)
+	}
+}
+
+// TestExecuteRequestPermitTimeout tests that a request waiting longer than the configured permit timeout fails.
+func TestExecuteRequestPermitTimeout(t *testing.T) {
+	release := make(chan struct{})
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}, func(config *ClientConfig) {
+		config.ClientOptions.Concurrency.PermitTimeout = 50 * time.Millisecond
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		var out map[string]interface{}
+		_, err := client.DoRequest(http.MethodGet, "/resources", nil, &out)
+		done <- err
+	}()
+	for client.ConcurrencyHandler.InUse() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	_, err := client.DoRequest(http.MethodGet, "/resources", nil, nil)
+	assert.ErrorIs(t, err, apierrors.ErrPermitTimeout)
+
+	close(release)
+	assert.NoError(t, <-done)
+}
+
+// TestExecuteRequestAdjustsConcurrency tests that the outcome of every GET attempt, retries included, feeds the
+// adaptive concurrency limit.
+func TestExecuteRequestAdjustsConcurrency(t *testing.T) {
+	t.Run("server errors", func(t *testing.T) {
+		client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}, func(config *ClientConfig) {
+			config.ClientOptions.Concurrency.MaxConcurrentRequests = 8
+			config.ClientOptions.Retry.BaseDelay = time.Millisecond
+		})
+
+		for i := 0; i < 2; i++ {
+			_, err := client.DoRequest(http.MethodGet, "/resources", nil, nil)
+			require.Error(t, err)
+		}
+		assert.Less(t, client.ConcurrencyHandler.Limit(), 8)
+	})
+
+	t.Run("slow responses", func(t *testing.T) {
+		client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(30 * time.Millisecond)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{}`))
+		}, func(config *ClientConfig) {
+			config.ClientOptions.Concurrency.MaxConcurrentRequests = 8
+			config.ClientOptions.Concurrency.ResponseTimeCriticalThreshold = 10 * time.Millisecond
+		})
+
+		for i := 0; i < 3; i++ {
+			var out map[string]interface{}
+			_, err := client.DoRequest(http.MethodGet, "/resources", nil, &out)
+			require.NoError(t, err)
+		}
+		assert.Less(t, client.ConcurrencyHandler.Limit(), 8)
+	})
+}
+
+// TestExecuteRequestAPIVersion tests that RequestOptions.APIVersion selects the Microsoft Graph version of a request
+// and is rejected by api handlers serving a single version.
+func TestExecuteRequestAPIVersion(t *testing.T) {
+	var paths []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tenant/oauth2/v2.0/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"graph-token","token_type":"Bearer","expires_in":3600}`))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := BuildClient(ClientConfig{
+		Auth:        AuthConfig{ClientID: "123e4567-e89b-12d3-a456-426614174000", ClientSecret: "validSecretWith16Chars"},
+		Environment: EnvironmentConfig{APIType: "msgraph", TenantID: "tenant", BaseURL: server.URL, AuthBaseURL: server.URL},
+		ClientOptions: ClientOptions{
+			Logging:     LoggingConfig{LogLevel: "LogLevelError"},
+			Concurrency: ConcurrencyConfig{MaxConcurrentRequests: 1},
+			Timeout:     TimeoutConfig{CustomTimeout: 5 * time.Second, TokenRefreshBufferPeriod: time.Minute, TotalRetryDuration: 30 * time.Second},
+		},
+	})
+	require.NoError(t, err)
+
+	var out map[string]interface{}
+	_, err = client.DoRequestWithOptions(http.MethodGet, "/users", nil, &out, RequestOptions{APIVersion: "beta"})
+	require.NoError(t, err)
+	_, err = client.DoRequest(http.MethodGet, "/users", nil, &out)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/beta/users", "/v1.0/users"}, paths)
+
+	generic := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	_, err = generic.DoRequestWithOptions(http.MethodGet, "/resources", nil, &out, RequestOptions{APIVersion: "v2"})
+	assert.ErrorContains(t, err, "does not support selecting api version")
+}
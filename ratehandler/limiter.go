@@ -0,0 +1,188 @@
+// ratehandler/limiter.go
+package ratehandler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/apiintegrations/endpointconfig"
+)
+
+// RateLimit is a request rate with a burst allowance.
+type RateLimit struct {
+	RequestsPerSecond float64 `json:"requests_per_second"` // Sustained request rate; zero or less means unlimited
+	Burst             int     `json:"burst,omitempty"`     // Requests allowed at once after a quiet period; defaults to the rate rounded up
+}
+
+// TokenBucket limits events to a sustained rate while allowing bursts. It is safe for concurrent use.
+type TokenBucket struct {
+	rate   float64 // Tokens added per second
+	burst  float64 // Maximum number of tokens
+	now    func() time.Time
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates a full TokenBucket for the rate limit, or returns nil if the rate is unlimited.
+func NewTokenBucket(limit RateLimit) *TokenBucket {
+	if limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = int(math.Ceil(limit.RequestsPerSecond))
+	}
+	return &TokenBucket{
+		rate:   limit.RequestsPerSecond,
+		burst:  float64(burst),
+		now:    time.Now,
+		tokens: float64(burst),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done. A wait that would end after the deadline of ctx fails
+// straight away without taking a token. A nil TokenBucket never waits.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	now := b.now()
+	b.refill(now)
+	var wait time.Duration
+	if b.tokens < 1 {
+		wait = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	}
+	if deadline, ok := ctx.Deadline(); ok && wait > 0 && now.Add(wait).After(deadline) {
+		b.mu.Unlock()
+		return fmt.Errorf("rate limit wait of %s exceeds the request deadline: %w", wait, context.DeadlineExceeded)
+	}
+	// Take the token now so that concurrent waiters queue up behind each other
+	b.tokens--
+	b.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Return the token that will not be used
+		b.restore()
+		return ctx.Err()
+	}
+}
+
+// Allow takes a token if one is available right away, reporting whether it did. A nil TokenBucket always allows.
+func (b *TokenBucket) Allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(b.now())
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// restore returns a token that was taken but not used.
+func (b *TokenBucket) restore() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(b.now())
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// refill adds the tokens accumulated since the last refill. The caller must hold the lock.
+func (b *TokenBucket) refill(now time.Time) {
+	if !b.last.IsZero() {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+}
+
+// Limiter limits the request rate of a client as a whole and per endpoint group. Endpoint groups are keyed by path
+// pattern as in endpointconfig, e.g. "/api/v1/computers-inventory" or "/v1.0/groups/{id}/members", and an endpoint
+// is limited by the most specific matching group as well as by the client limit.
+type Limiter struct {
+	client    *TokenBucket
+	endpoints map[string]*TokenBucket
+	patterns  []string
+}
+
+// NewLimiter creates a Limiter, or returns nil if no limit is set.
+func NewLimiter(client RateLimit, endpoints map[string]RateLimit) *Limiter {
+	limiter := &Limiter{
+		client:    NewTokenBucket(client),
+		endpoints: make(map[string]*TokenBucket),
+	}
+	for pattern, limit := range endpoints {
+		if bucket := NewTokenBucket(limit); bucket != nil {
+			limiter.endpoints[pattern] = bucket
+			limiter.patterns = append(limiter.patterns, pattern)
+		}
+	}
+	sort.Strings(limiter.patterns)
+	if limiter.client == nil && len(limiter.patterns) == 0 {
+		return nil
+	}
+	return limiter
+}
+
+// Wait blocks until a request to endpoint is allowed by its endpoint group and by the client limit, or ctx is done.
+// A nil Limiter never waits.
+func (l *Limiter) Wait(ctx context.Context, endpoint string) error {
+	if l == nil {
+		return nil
+	}
+	var group *TokenBucket
+	if pattern, ok := endpointconfig.BestMatch(l.patterns, endpoint); ok {
+		group = l.endpoints[pattern]
+		if err := group.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if err := l.client.Wait(ctx); err != nil {
+		// Return the group token of the request that is not sent
+		if group != nil {
+			group.restore()
+		}
+		return err
+	}
+	return nil
+}
+
+// Allow takes a token for a request to endpoint from its endpoint group and from the client limit if both have one
+// available right away, reporting whether it did. No token is taken when either limit would have to wait.
+// A nil Limiter always allows.
+func (l *Limiter) Allow(endpoint string) bool {
+	if l == nil {
+		return true
+	}
+	var group *TokenBucket
+	if pattern, ok := endpointconfig.BestMatch(l.patterns, endpoint); ok {
+		group = l.endpoints[pattern]
+		if !group.Allow() {
+			return false
+		}
+	}
+	if !l.client.Allow() {
+		if group != nil {
+			group.restore()
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+// ratehandler/limiter_test.go
+package ratehandler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTokenBucketBurst tests that a full bucket allows its burst at once and then limits to the rate.
+func TestTokenBucketBurst(t *testing.T) {
+	bucket := NewTokenBucket(RateLimit{RequestsPerSecond: 10, Burst: 2})
+
+	start := time.Now()
+	require.NoError(t, bucket.Wait(context.Background()))
+	require.NoError(t, bucket.Wait(context.Background()))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	require.NoError(t, bucket.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+// TestTokenBucketDeadline tests that waits past the context deadline fail straight away without taking a token.
+func TestTokenBucketDeadline(t *testing.T) {
+	bucket := NewTokenBucket(RateLimit{RequestsPerSecond: 5, Burst: 1})
+	require.NoError(t, bucket.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := bucket.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 10*time.Millisecond)
+
+	bucket.mu.Lock()
+	assert.Greater(t, bucket.tokens, -0.5, "the token is not taken")
+	bucket.mu.Unlock()
+}
+
+// TestTokenBucketUnlimited tests that unlimited rates do not create a bucket and never wait.
+func TestTokenBucketUnlimited(t *testing.T) {
+	bucket := NewTokenBucket(RateLimit{})
+	assert.Nil(t, bucket)
+	assert.NoError(t, bucket.Wait(context.Background()))
+
+	defaultBurst := NewTokenBucket(RateLimit{RequestsPerSecond: 2.5})
+	assert.Equal(t, float64(3), defaultBurst.burst)
+}
+
+// TestLimiterEndpointGroups tests that endpoint groups are limited separately from other endpoints.
+func TestLimiterEndpointGroups(t *testing.T) {
+	assert.Nil(t, NewLimiter(RateLimit{}, nil))
+
+	limiter := NewLimiter(RateLimit{}, map[string]RateLimit{
+		"/api/v1/computers-inventory": {RequestsPerSecond: 5, Burst: 1},
+	})
+	require.NotNil(t, limiter)
+
+	start := time.Now()
+	require.NoError(t, limiter.Wait(context.Background(), "/api/v1/computers-inventory/1"))
+	require.NoError(t, limiter.Wait(context.Background(), "/api/v1/departments"))
+	require.NoError(t, limiter.Wait(context.Background(), "/api/v1/departments"))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	require.NoError(t, limiter.Wait(context.Background(), "/api/v1/computers-inventory/2?section=GENERAL"))
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+// TestLimiterAllow tests that Allow only takes tokens that are available right away, from both limits or from neither.
+func TestLimiterAllow(t *testing.T) {
+	var unlimited *Limiter
+	assert.True(t, unlimited.Allow("/api/v1/departments"))
+
+	limiter := NewLimiter(RateLimit{RequestsPerSecond: 1, Burst: 2}, map[string]RateLimit{
+		"/api/v1/computers-inventory": {RequestsPerSecond: 1, Burst: 1},
+	})
+	require.NotNil(t, limiter)
+
+	assert.True(t, limiter.Allow("/api/v1/computers-inventory/1"))
+	assert.False(t, limiter.Allow("/api/v1/computers-inventory/2"), "the endpoint group is used up")
+	assert.True(t, limiter.Allow("/api/v1/departments"))
+	assert.False(t, limiter.Allow("/api/v1/departments"), "the client limit is used up")
+
+	group := limiter.endpoints["/api/v1/computers-inventory"]
+	group.mu.Lock()
+	group.tokens = 1
+	group.mu.Unlock()
+	assert.False(t, limiter.Allow("/api/v1/computers-inventory/3"))
+	group.mu.Lock()
+	assert.InDelta(t, 1, group.tokens, 0.1, "the group token is returned when the client limit refuses")
+	group.mu.Unlock()
+}
+
+// TestLimiterWaitRestoresGroupToken tests that a request failing on the client limit does not use up a token of its
+// endpoint group.
+func TestLimiterWaitRestoresGroupToken(t *testing.T) {
+	limiter := NewLimiter(RateLimit{RequestsPerSecond: 1, Burst: 1}, map[string]RateLimit{
+		"/api/v1/computers-inventory": {RequestsPerSecond: 1, Burst: 2},
+	})
+	require.NoError(t, limiter.Wait(context.Background(), "/api/v1/computers-inventory/1"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx, "/api/v1/computers-inventory/2"), context.DeadlineExceeded)
+
+	group := limiter.endpoints["/api/v1/computers-inventory"]
+	group.mu.Lock()
+	assert.InDelta(t, 1, group.tokens, 0.1, "the group token is returned when the client limit fails")
+	group.mu.Unlock()
+}
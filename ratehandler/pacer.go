@@ -0,0 +1,187 @@
+// ratehandler/pacer.go
+package ratehandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"go.uber.org/zap"
+)
+
+// DefaultPacingThreshold is the share of a rate limit budget left at which the pacer starts spacing requests out.
+const DefaultPacingThreshold = 0.2
+
+// budget is the latest rate limit budget reported for a host and resource.
+type budget struct {
+	limit     float64
+	remaining float64   // Requests left, counted down locally between responses
+	reset     time.Time // Time the budget resets
+	next      time.Time // Earliest time the next paced request may be sent
+}
+
+// Pacer spaces requests out as the rate limit budgets reported by the server near exhaustion, and holds every
+// request to a host back while the server has asked for a pause with Retry-After. It learns from every response
+// through Observe, so all goroutines slow down together rather than only the one that got throttled.
+// It is safe for concurrent use.
+type Pacer struct {
+	Threshold float64 // Share of a budget left at which requests are spaced out evenly until the reset
+	BasePath  string  // Path of the API base URL, e.g. "/api/v3" on GitHub Enterprise Server, which precedes the resource segment
+	log       logger.Logger
+	now       func() time.Time
+
+	mu           sync.Mutex
+	budgets      map[string]*budget   // Budgets by host and resource
+	resources    map[string]string    // Resource last reported by host and first path segment after BasePath
+	blockedUntil map[string]time.Time // Pauses requested with Retry-After, by host
+}
+
+// NewPacer creates a Pacer starting to space requests out when threshold of a budget is left, or
+// DefaultPacingThreshold if threshold is zero or less.
+func NewPacer(threshold float64, log logger.Logger) *Pacer {
+	if threshold <= 0 {
+		threshold = DefaultPacingThreshold
+	}
+	return &Pacer{
+		Threshold:    threshold,
+		log:          log,
+		now:          time.Now,
+		budgets:      make(map[string]*budget),
+		resources:    make(map[string]string),
+		blockedUntil: make(map[string]time.Time),
+	}
+}
+
+// Observe updates the budgets with the rate limit headers of resp and records any pause the server asked for.
+// A nil Pacer ignores responses.
+func (p *Pacer) Observe(resp *http.Response) {
+	if p == nil || resp == nil || resp.Request == nil {
+		return
+	}
+	host, segment := p.pacingKey(resp.Request.URL)
+	now := p.now()
+	status, hasStatus := ParseRateLimitStatus(resp, now)
+
+	var pause time.Duration
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusForbidden:
+		pause = ParseRateLimitHeaders(resp, p.log)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if hasStatus {
+		if status.Resource != "" {
+			p.resources[host+segment] = status.Resource
+		}
+		key := host + "|" + p.resources[host+segment]
+		b, ok := p.budgets[key]
+		if !ok {
+			b = &budget{}
+			p.budgets[key] = b
+		}
+		b.limit, b.remaining, b.reset = status.Limit, status.Remaining, status.Reset
+	}
+
+	if pause > 0 {
+		until := now.Add(pause)
+		if until.After(p.blockedUntil[host]) {
+			p.blockedUntil[host] = until
+			p.log.Warn("Server requested a pause, holding back all requests to the host", zap.String("host", host), zap.Duration("pause", pause))
+		}
+	}
+}
+
+// Wait blocks until a request to rawURL may be sent, or ctx is done. Requests wait while the host has asked for a
+// pause, until the reset once a budget is used up, and are spaced evenly over the time left once less than
+// Threshold of a budget remains. A nil Pacer never waits.
+func (p *Pacer) Wait(ctx context.Context, rawURL string) error {
+	if p == nil {
+		return nil
+	}
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	host, segment := p.pacingKey(parsedURL)
+
+	p.mu.Lock()
+	now := p.now()
+	sendAt := now
+	if b, ok := p.budgets[host+"|"+p.resources[host+segment]]; ok && now.Before(b.reset) {
+		switch {
+		case b.remaining < 1:
+			sendAt = b.reset
+		case b.limit <= 0 || b.remaining/b.limit <= p.Threshold:
+			interval := b.reset.Sub(now) / time.Duration(b.remaining)
+			if b.next.After(sendAt) {
+				sendAt = b.next
+			}
+			b.next = sendAt.Add(interval)
+		}
+		b.remaining--
+	}
+	if until := p.blockedUntil[host]; until.After(sendAt) {
+		sendAt = until
+	}
+	p.mu.Unlock()
+
+	wait := sendAt.Sub(now)
+	if wait <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && sendAt.After(deadline) {
+		return fmt.Errorf("rate limit pacing wait of %s exceeds the request deadline: %w", wait, context.DeadlineExceeded)
+	}
+	p.log.Debug("Pacing request to stay within the rate limit", zap.String("host", host), zap.Duration("wait", wait))
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pacingKey returns the host of u and its first path segment after BasePath, which selects the resource a budget
+// belongs to, e.g. GitHub's /search endpoints count against the "search" resource rather than "core".
+func (p *Pacer) pacingKey(u *url.URL) (host, segment string) {
+	path := u.Path
+	if base := strings.TrimSuffix(p.BasePath, "/"); base != "" && (path == base || strings.HasPrefix(path, base+"/")) {
+		path = path[len(base):]
+	}
+	segment, _, _ = strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return u.Host, "/" + segment
+}
+
+// PacingTransport is an http.RoundTripper that lets a Pacer observe every response.
+type PacingTransport struct {
+	Base  http.RoundTripper // Transport used to send requests; http.DefaultTransport when nil
+	Pacer *Pacer            // Pacer observing the responses
+}
+
+// NewPacingTransport creates a PacingTransport sending requests through base.
+func NewPacingTransport(base http.RoundTripper, pacer *Pacer) *PacingTransport {
+	return &PacingTransport{Base: base, Pacer: pacer}
+}
+
+// RoundTrip sends req and passes the response to the pacer.
+func (t *PacingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err == nil {
+		t.Pacer.Observe(resp)
+	}
+	return resp, err
+}
@@ -0,0 +1,165 @@
+// ratehandler/pacer_test.go
+package ratehandler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newPacerTestResponse builds a response to a GET request for rawURL with the given headers.
+func newPacerTestResponse(rawURL string, statusCode int, headers map[string]string) *http.Response {
+	resp := &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Request:    httptest.NewRequest(http.MethodGet, rawURL, nil),
+	}
+	for name, value := range headers {
+		resp.Header.Set(name, value)
+	}
+	return resp
+}
+
+// TestParseRateLimitStatus tests reading budgets from the supported rate limit headers.
+func TestParseRateLimitStatus(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected RateLimitStatus
+		ok       bool
+	}{
+		{
+			name:     "IETF headers",
+			headers:  map[string]string{"RateLimit-Limit": "100", "RateLimit-Remaining": "40", "RateLimit-Reset": "30"},
+			expected: RateLimitStatus{Limit: 100, Remaining: 40, Reset: now.Add(30 * time.Second)},
+			ok:       true,
+		},
+		{
+			name:     "IETF structured fields",
+			headers:  map[string]string{"RateLimit": `"default";r=50;t=30`, "RateLimit-Policy": `"default";q=100;w=60`},
+			expected: RateLimitStatus{Resource: "default", Limit: 100, Remaining: 50, Reset: now.Add(30 * time.Second)},
+			ok:       true,
+		},
+		{
+			name:     "IETF earlier draft",
+			headers:  map[string]string{"RateLimit": "limit=100, remaining=50, reset=5", "RateLimit-Policy": "100;w=60"},
+			expected: RateLimitStatus{Limit: 100, Remaining: 50, Reset: now.Add(5 * time.Second)},
+			ok:       true,
+		},
+		{
+			name:     "GitHub",
+			headers:  map[string]string{"x-ratelimit-limit": "30", "x-ratelimit-remaining": "12", "x-ratelimit-reset": "1714565000", "x-ratelimit-resource": "search"},
+			expected: RateLimitStatus{Resource: "search", Limit: 30, Remaining: 12, Reset: time.Unix(1714565000, 0)},
+			ok:       true,
+		},
+		{
+			name:     "Reset in seconds",
+			headers:  map[string]string{"X-RateLimit-Remaining": "9", "X-RateLimit-Reset": "60"},
+			expected: RateLimitStatus{Remaining: 9, Reset: now.Add(time.Minute)},
+			ok:       true,
+		},
+		{
+			name:     "Microsoft Graph",
+			headers:  map[string]string{"x-ms-throttle-limit-percentage": "0.9", "x-ms-throttle-scope": "Tenant_Application/ReadWrite/tenant/app"},
+			expected: RateLimitStatus{Resource: "Tenant_Application/ReadWrite/tenant/app", Limit: 100, Remaining: 10.000000000000002, Reset: now.Add(graphThrottleWindow)},
+			ok:       true,
+		},
+		{
+			name:    "No headers",
+			headers: map[string]string{"Content-Type": "application/json"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, ok := ParseRateLimitStatus(newPacerTestResponse("https://api.example.com/", http.StatusOK, tt.headers), now)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.expected.Resource, status.Resource)
+				assert.Equal(t, tt.expected.Limit, status.Limit)
+				assert.InDelta(t, tt.expected.Remaining, status.Remaining, 1e-9)
+				assert.True(t, tt.expected.Reset.Equal(status.Reset), "reset %s, expected %s", status.Reset, tt.expected.Reset)
+			}
+		})
+	}
+}
+
+// newTestPacer returns a Pacer with a mock logger.
+func newTestPacer() *Pacer {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Warn", mock.Anything, mock.Anything).Maybe()
+	return NewPacer(0, mockLog)
+}
+
+// TestPacerSpacesRequests tests that requests are spaced out once the budget nears exhaustion.
+func TestPacerSpacesRequests(t *testing.T) {
+	pacer := newTestPacer()
+	pacer.Observe(newPacerTestResponse("https://api.github.com/repos/a/b", http.StatusOK, map[string]string{
+		"x-ratelimit-limit": "5000", "x-ratelimit-remaining": "4000", "x-ratelimit-reset": "60", "x-ratelimit-resource": "core",
+	}))
+
+	start := time.Now()
+	require.NoError(t, pacer.Wait(context.Background(), "https://api.github.com/repos/a/c"))
+	require.NoError(t, pacer.Wait(context.Background(), "https://api.github.com/repos/a/c"))
+	assert.Less(t, time.Since(start), 20*time.Millisecond, "requests are not paced while the budget is healthy")
+
+	pacer.Observe(newPacerTestResponse("https://api.github.com/repos/a/b", http.StatusOK, map[string]string{
+		"x-ratelimit-limit": "5000", "x-ratelimit-remaining": "2", "x-ratelimit-reset": "0.4", "x-ratelimit-resource": "core",
+	}))
+	start = time.Now()
+	require.NoError(t, pacer.Wait(context.Background(), "https://api.github.com/repos/a/c"))
+	require.NoError(t, pacer.Wait(context.Background(), "https://api.github.com/repos/a/c"))
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+// TestPacerResources tests that budgets of different resources of a host are kept apart.
+func TestPacerResources(t *testing.T) {
+	pacer := newTestPacer()
+	pacer.Observe(newPacerTestResponse("https://api.github.com/search/code?q=x", http.StatusOK, map[string]string{
+		"x-ratelimit-limit": "30", "x-ratelimit-remaining": "0", "x-ratelimit-reset": "30", "x-ratelimit-resource": "search",
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, pacer.Wait(ctx, "https://api.github.com/repos/a/b"))
+	assert.ErrorIs(t, pacer.Wait(ctx, "https://api.github.com/search/issues?q=y"), context.DeadlineExceeded)
+}
+
+// TestPacerResourcesBasePath tests that resources are told apart by the segment after the API base path, as on
+// GitHub Enterprise Server where every endpoint is served under /api/v3.
+func TestPacerResourcesBasePath(t *testing.T) {
+	pacer := newTestPacer()
+	pacer.BasePath = "/api/v3"
+	pacer.Observe(newPacerTestResponse("https://ghes.corp/api/v3/search/code?q=x", http.StatusOK, map[string]string{
+		"x-ratelimit-limit": "30", "x-ratelimit-remaining": "0", "x-ratelimit-reset": "30", "x-ratelimit-resource": "search",
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, pacer.Wait(ctx, "https://ghes.corp/api/v3/repos/a/b"))
+	assert.ErrorIs(t, pacer.Wait(ctx, "https://ghes.corp/api/v3/search/issues?q=y"), context.DeadlineExceeded)
+}
+
+// TestPacerRetryAfter tests that a requested pause holds back every request to the host.
+func TestPacerRetryAfter(t *testing.T) {
+	pacer := newTestPacer()
+	pacer.Observe(newPacerTestResponse("https://graph.microsoft.com/v1.0/users", http.StatusTooManyRequests, map[string]string{"Retry-After": "10"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	assert.ErrorIs(t, pacer.Wait(ctx, "https://graph.microsoft.com/v1.0/groups"), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 20*time.Millisecond, "waits past the deadline fail straight away")
+	assert.NoError(t, pacer.Wait(ctx, "https://login.microsoftonline.com/tenant/oauth2/v2.0/token"))
+
+	var nilPacer *Pacer
+	assert.NoError(t, nilPacer.Wait(ctx, "https://graph.microsoft.com/v1.0/groups"))
+}
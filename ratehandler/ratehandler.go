@@ -19,8 +19,6 @@ behavior accordingly.
 package ratehandler
 
 import (
-	"math"
-	"math/rand"
 	"net/http"
 	"strconv"
 	"time"
@@ -36,23 +34,15 @@ const (
 	jitterFactor = 0.5                    // Random jitter factor
 )
 
+// defaultBackoff is the exponential backoff used when no strategy is configured.
+var defaultBackoff = ExponentialBackoff{BaseDelay: baseDelay, MaxDelay: maxDelay, JitterFactor: jitterFactor}
+
 // CalculateBackoff calculates the next delay for retry with exponential backoff and jitter.
 // The baseDelay is the initial delay duration, which is exponentially increased on each retry.
 // The jitterFactor adds randomness to the delay to avoid simultaneous retries (thundering herd problem).
 // The delay is capped at maxDelay to prevent excessive wait times.
 func CalculateBackoff(retry int) time.Duration {
-	if retry < 0 {
-		retry = 0 // Ensure non-negative retry count
-	}
-
-	delay := float64(baseDelay) * math.Pow(2, float64(retry))
-	jitter := (rand.Float64() - 0.5) * jitterFactor * 2.0 // Random value between -jitterFactor and +jitterFactor
-	delayWithJitter := delay * (1.0 + jitter)
-
-	if delayWithJitter > float64(maxDelay) {
-		return maxDelay
-	}
-	return time.Duration(delayWithJitter)
+	return defaultBackoff.Delay(retry, 0)
 }
 
 // ParseRateLimitHeaders parses common rate limit headers and adjusts behavior accordingly.
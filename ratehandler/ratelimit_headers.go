@@ -0,0 +1,162 @@
+// ratehandler/ratelimit_headers.go
+package ratehandler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// graphThrottleWindow is the window assumed for Microsoft Graph throttling, which reports only the share of the
+// limit used and not when it resets.
+const graphThrottleWindow = 10 * time.Second
+
+// RateLimitStatus is the rate limit budget reported by a response.
+type RateLimitStatus struct {
+	Resource  string    // Resource the budget applies to, e.g. GitHub's "core" or "search", or a Graph throttle scope
+	Limit     float64   // Requests allowed in the window
+	Remaining float64   // Requests left in the window
+	Reset     time.Time // Time the window resets
+}
+
+// ParseRateLimitStatus reads the rate limit budget from the response headers. It understands the IETF
+// RateLimit-Limit/-Remaining/-Reset headers, the structured RateLimit and RateLimit-Policy headers, GitHub's and other
+// vendors' X-RateLimit-* headers including X-RateLimit-Resource, and Microsoft Graph's x-ms-throttle-* headers.
+// The second result is false when the response reports no budget.
+func ParseRateLimitStatus(resp *http.Response, now time.Time) (RateLimitStatus, bool) {
+	h := resp.Header
+	if status, ok := parseIETFRateLimit(h, now); ok {
+		return status, true
+	}
+	if status, ok := parseXRateLimit(h, now); ok {
+		return status, true
+	}
+	return parseGraphThrottle(h, now)
+}
+
+// parseIETFRateLimit reads the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers of the earlier
+// drafts, or the structured RateLimit header of later drafts, e.g. `"default";r=50;t=30`, along with the limit from
+// RateLimit-Policy, e.g. `"default";q=100;w=60` or `100;w=60`. Resets are given in seconds from now.
+func parseIETFRateLimit(h http.Header, now time.Time) (RateLimitStatus, bool) {
+	var status RateLimitStatus
+	limit, hasLimit := parseFloatHeader(h.Get("RateLimit-Limit"))
+	remaining, hasRemaining := parseFloatHeader(h.Get("RateLimit-Remaining"))
+	reset, hasReset := parseFloatHeader(h.Get("RateLimit-Reset"))
+
+	if value := h.Get("RateLimit"); value != "" {
+		name, params := parseStructuredItem(value)
+		status.Resource = name
+		if v, ok := firstParam(params, "r", "remaining"); ok {
+			remaining, hasRemaining = v, true
+		}
+		if v, ok := firstParam(params, "t", "reset"); ok {
+			reset, hasReset = v, true
+		}
+		if v, ok := firstParam(params, "limit"); ok {
+			limit, hasLimit = v, true
+		}
+	}
+	if value := h.Get("RateLimit-Policy"); value != "" && !hasLimit {
+		name, params := parseStructuredItem(value)
+		if v, ok := firstParam(params, "q"); ok {
+			limit, hasLimit = v, true
+		} else if v, err := strconv.ParseFloat(name, 64); err == nil {
+			limit, hasLimit = v, true
+		}
+		if v, ok := firstParam(params, "w"); ok && !hasReset {
+			reset, hasReset = v, true
+		}
+	}
+
+	if !hasRemaining || !hasReset {
+		return status, false
+	}
+	status.Limit = limit
+	status.Remaining = remaining
+	status.Reset = now.Add(time.Duration(reset * float64(time.Second)))
+	return status, true
+}
+
+// parseXRateLimit reads X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset and X-RateLimit-Resource as
+// sent by GitHub and many other APIs. Resets above one billion are taken as Unix times, others as seconds from now.
+func parseXRateLimit(h http.Header, now time.Time) (RateLimitStatus, bool) {
+	remaining, hasRemaining := parseFloatHeader(h.Get("X-RateLimit-Remaining"))
+	reset, hasReset := parseFloatHeader(h.Get("X-RateLimit-Reset"))
+	if !hasRemaining || !hasReset {
+		return RateLimitStatus{}, false
+	}
+	limit, _ := parseFloatHeader(h.Get("X-RateLimit-Limit"))
+
+	status := RateLimitStatus{
+		Resource:  h.Get("X-RateLimit-Resource"),
+		Limit:     limit,
+		Remaining: remaining,
+	}
+	if reset > 1e9 {
+		status.Reset = time.Unix(int64(reset), 0)
+	} else {
+		status.Reset = now.Add(time.Duration(reset * float64(time.Second)))
+	}
+	return status, true
+}
+
+// parseGraphThrottle reads x-ms-throttle-limit-percentage and x-ms-throttle-scope, which Microsoft Graph sends once
+// an application has used 80% of a limit. As Graph does not report counts, the budget is expressed as 100 units per
+// throttle window.
+func parseGraphThrottle(h http.Header, now time.Time) (RateLimitStatus, bool) {
+	used, ok := parseFloatHeader(h.Get("X-Ms-Throttle-Limit-Percentage"))
+	if !ok {
+		return RateLimitStatus{}, false
+	}
+	remaining := 100 * (1 - used)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return RateLimitStatus{
+		Resource:  h.Get("X-Ms-Throttle-Scope"),
+		Limit:     100,
+		Remaining: remaining,
+		Reset:     now.Add(graphThrottleWindow),
+	}, true
+}
+
+// parseStructuredItem splits a structured field item such as `"default";q=100;w=60` into its bare item and
+// parameters. Comma separated parameters of the earlier drafts, e.g. `limit=100, remaining=50`, are accepted too.
+func parseStructuredItem(value string) (string, map[string]string) {
+	params := make(map[string]string)
+	var name string
+	for i, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == ',' }) {
+		key, arg, hasArg := strings.Cut(strings.TrimSpace(part), "=")
+		if !hasArg {
+			if i == 0 {
+				name = strings.Trim(key, "\"")
+			}
+			continue
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(arg), "\"")
+	}
+	return name, params
+}
+
+// firstParam returns the numeric value of the first of names present in params.
+func firstParam(params map[string]string, names ...string) (float64, bool) {
+	for _, name := range names {
+		if value, ok := params[name]; ok {
+			return parseFloatHeader(value)
+		}
+	}
+	return 0, false
+}
+
+// parseFloatHeader parses a numeric header value.
+func parseFloatHeader(value string) (float64, bool) {
+	if value == "" {
+		return 0, false
+	}
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, false
+	}
+	return number, true
+}
@@ -0,0 +1,152 @@
+// ratehandler/retrypolicy.go
+package ratehandler
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
+	"github.com/deploymenttheory/go-api-http-client/status"
+)
+
+// RetryPolicy decides whether a failed attempt of a request is retried and how long to wait before the retry.
+// Implementations must be safe for concurrent use.
+type RetryPolicy interface {
+	// ShouldRetry reports whether an attempt that failed with resp, or with err if no response was received, should be
+	// retried.
+	ShouldRetry(req *http.Request, resp *http.Response, err error) bool
+	// Backoff returns the delay before retry number attempt, starting at 1. previous is the delay before the
+	// previous retry, or zero for the first.
+	Backoff(attempt int, previous time.Duration) time.Duration
+}
+
+// BackoffStrategy calculates the delay before a retry.
+type BackoffStrategy interface {
+	Delay(attempt int, previous time.Duration) time.Duration
+}
+
+// ExponentialBackoff doubles the delay on each retry, starting from BaseDelay, with random jitter of up to
+// JitterFactor in either direction, and caps it at MaxDelay.
+type ExponentialBackoff struct {
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	JitterFactor float64
+}
+
+// Delay returns the delay before retry number attempt.
+func (b ExponentialBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	if attempt < 0 {
+		attempt = 0 // Ensure non-negative retry count
+	}
+
+	delay := float64(b.BaseDelay) * math.Pow(2, float64(attempt))
+	jitter := (rand.Float64() - 0.5) * b.JitterFactor * 2.0 // Random value between -JitterFactor and +JitterFactor
+	delayWithJitter := delay * (1.0 + jitter)
+
+	if delayWithJitter > float64(b.MaxDelay) {
+		return b.MaxDelay
+	}
+	return time.Duration(delayWithJitter)
+}
+
+// DecorrelatedJitterBackoff picks a random delay between BaseDelay and three times the previous delay, capped at
+// MaxDelay. Concurrent clients retrying after the same failure spread out more than with exponential backoff.
+type DecorrelatedJitterBackoff struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// Delay returns the delay before the next retry based on the previous delay.
+func (b DecorrelatedJitterBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	if previous < b.BaseDelay {
+		previous = b.BaseDelay
+	}
+	upper := 3 * previous
+	delay := b.BaseDelay
+	if upper > b.BaseDelay {
+		delay += time.Duration(rand.Int63n(int64(upper - b.BaseDelay)))
+	}
+	if delay > b.MaxDelay {
+		return b.MaxDelay
+	}
+	return delay
+}
+
+// ConstantBackoff waits the same delay before every retry.
+type ConstantBackoff struct {
+	Interval time.Duration
+}
+
+// Delay returns the constant delay.
+func (b ConstantBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	return b.Interval
+}
+
+// NewBackoffStrategy creates the built-in strategy named "exponential", "decorrelated" or "constant". An empty name
+// selects exponential backoff. Zero delays default to 100ms for the base delay and 5s for the maximum; the constant
+// strategy waits the base delay.
+func NewBackoffStrategy(name string, base, maximum time.Duration) (BackoffStrategy, error) {
+	if base <= 0 {
+		base = baseDelay
+	}
+	if maximum <= 0 {
+		maximum = maxDelay
+	}
+	switch strings.ToLower(name) {
+	case "", "exponential":
+		return ExponentialBackoff{BaseDelay: base, MaxDelay: maximum, JitterFactor: jitterFactor}, nil
+	case "decorrelated":
+		return DecorrelatedJitterBackoff{BaseDelay: base, MaxDelay: maximum}, nil
+	case "constant":
+		return ConstantBackoff{Interval: base}, nil
+	default:
+		return nil, fmt.Errorf("unsupported backoff strategy %q, expected \"exponential\", \"decorrelated\" or \"constant\"", name)
+	}
+}
+
+// DefaultRetryable retries responses that status.IsRetryableStatusCode classifies: 408, 429, 500, 502, 503 and 504.
+// Requests that failed without a response are retried when the error class is retryable, e.g. connection resets,
+// timeouts and HTTP/2 GOAWAY, but not missing hosts or rejected certificates.
+func DefaultRetryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return apierrors.ClassifyTransportError(err).Retryable()
+	}
+	return resp != nil && status.IsRetryableStatusCode(resp.StatusCode)
+}
+
+// Policy is a RetryPolicy combining a BackoffStrategy with a retry predicate.
+type Policy struct {
+	Strategy  BackoffStrategy                                              // Strategy calculating the delays; exponential backoff when nil
+	Retryable func(req *http.Request, resp *http.Response, err error) bool // Retry predicate; DefaultRetryable when nil
+}
+
+// NewRetryPolicy creates a Policy retrying with DefaultRetryable and waiting as strategy calculates.
+func NewRetryPolicy(strategy BackoffStrategy) *Policy {
+	return &Policy{Strategy: strategy, Retryable: DefaultRetryable}
+}
+
+// DefaultRetryPolicy returns the policy used when none is configured: DefaultRetryable with exponential backoff from
+// 100ms up to 5s.
+func DefaultRetryPolicy() *Policy {
+	return NewRetryPolicy(defaultBackoff)
+}
+
+// ShouldRetry reports whether the attempt should be retried.
+func (p *Policy) ShouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if p.Retryable == nil {
+		return DefaultRetryable(req, resp, err)
+	}
+	return p.Retryable(req, resp, err)
+}
+
+// Backoff returns the delay before retry number attempt.
+func (p *Policy) Backoff(attempt int, previous time.Duration) time.Duration {
+	if p.Strategy == nil {
+		return defaultBackoff.Delay(attempt, previous)
+	}
+	return p.Strategy.Delay(attempt, previous)
+}
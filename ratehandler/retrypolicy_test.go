@@ -0,0 +1,79 @@
+// ratehandler/retrypolicy_test.go
+package ratehandler
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBackoffStrategies tests that the built-in strategies stay within their bounds.
+func TestBackoffStrategies(t *testing.T) {
+	exponential := ExponentialBackoff{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, JitterFactor: 0.5}
+	assert.GreaterOrEqual(t, exponential.Delay(1, 0), 100*time.Millisecond)
+	assert.LessOrEqual(t, exponential.Delay(1, 0), 300*time.Millisecond)
+	assert.Equal(t, time.Second, exponential.Delay(10, 0))
+
+	decorrelated := DecorrelatedJitterBackoff{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for i := 0; i < 100; i++ {
+		delay := decorrelated.Delay(2, 200*time.Millisecond)
+		assert.GreaterOrEqual(t, delay, 100*time.Millisecond)
+		assert.Less(t, delay, 600*time.Millisecond)
+	}
+	assert.LessOrEqual(t, decorrelated.Delay(5, time.Minute), time.Second)
+
+	constant := ConstantBackoff{Interval: 250 * time.Millisecond}
+	assert.Equal(t, 250*time.Millisecond, constant.Delay(7, time.Second))
+}
+
+// TestNewBackoffStrategy tests the strategy names and delay defaults.
+func TestNewBackoffStrategy(t *testing.T) {
+	strategy, err := NewBackoffStrategy("", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, ExponentialBackoff{BaseDelay: baseDelay, MaxDelay: maxDelay, JitterFactor: jitterFactor}, strategy)
+
+	strategy, err = NewBackoffStrategy("Decorrelated", time.Second, 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, DecorrelatedJitterBackoff{BaseDelay: time.Second, MaxDelay: 10 * time.Second}, strategy)
+
+	strategy, err = NewBackoffStrategy("constant", 2*time.Second, 0)
+	require.NoError(t, err)
+	assert.Equal(t, ConstantBackoff{Interval: 2 * time.Second}, strategy)
+
+	_, err = NewBackoffStrategy("linear", 0, 0)
+	assert.Error(t, err)
+}
+
+// TestDefaultRetryPolicy tests which attempts the default policy retries.
+func TestDefaultRetryPolicy(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example.com/items", nil)
+
+	for code, expected := range map[int]bool{
+		http.StatusRequestTimeout:      true,
+		http.StatusTooManyRequests:     true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusBadRequest:          false,
+		http.StatusNotFound:            false,
+		http.StatusInternalServerError: true,
+	} {
+		assert.Equal(t, expected, policy.ShouldRetry(req, &http.Response{StatusCode: code}, nil), "status %d", code)
+	}
+	assert.False(t, policy.ShouldRetry(req, nil, errors.New("unsupported protocol scheme")))
+	assert.True(t, policy.ShouldRetry(req, nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}))
+	assert.LessOrEqual(t, policy.Backoff(1, 0), maxDelay)
+
+	custom := &Policy{
+		Strategy:  ConstantBackoff{Interval: time.Millisecond},
+		Retryable: func(req *http.Request, resp *http.Response, err error) bool { return err != nil },
+	}
+	assert.True(t, custom.ShouldRetry(req, nil, errors.New("connection reset")))
+	assert.False(t, custom.ShouldRetry(req, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil))
+	assert.Equal(t, time.Millisecond, custom.Backoff(3, 0))
+}
@@ -0,0 +1,194 @@
+// response/csv.go
+package response
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"go.uber.org/zap"
+)
+
+// unmarshalCSV decodes CSV content into a *[][]string, holding every record including the header, or into a
+// pointer to a slice of structs (or struct pointers) with one element per data record. Columns are matched to
+// struct fields by the `csv:"name"` tag, or else the field name; the comparison ignores case, spaces, hyphens
+// and underscores, so a "Serial Number" column fills a SerialNumber field. Fields tagged `csv:"-"` and
+// columns without a matching field are skipped.
+func unmarshalCSV(reader io.Reader, out interface{}, log logger.Logger, mimeType string) error {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1 // Allow rows with fewer or more fields than the header
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		log.Error("CSV Unmarshal error", zap.Error(err))
+		return err
+	}
+
+	if raw, ok := out.(*[][]string); ok {
+		*raw = records
+		log.Info("Successfully unmarshalled CSV response", zap.String("content type", mimeType))
+		return nil
+	}
+
+	if err := decodeCSVRecords(records, out); err != nil {
+		log.Error("CSV Unmarshal error", zap.Error(err))
+		return err
+	}
+	log.Info("Successfully unmarshalled CSV response", zap.String("content type", mimeType))
+	return nil
+}
+
+// decodeCSVRecords decodes a header record and data records into a pointer to a slice of structs.
+func decodeCSVRecords(records [][]string, out interface{}) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Slice {
+		return errors.New("output parameter is not suitable for CSV data (*[][]string or pointer to a slice of structs)")
+	}
+	slice := target.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot decode CSV into a slice of %s", elemType)
+	}
+
+	result := reflect.MakeSlice(slice.Type(), 0, len(records))
+	if len(records) == 0 {
+		slice.Set(result)
+		return nil
+	}
+
+	columns := csvColumnFields(records[0], structType)
+	for row, record := range records[1:] {
+		item := reflect.New(structType).Elem()
+		for column, value := range record {
+			if column >= len(columns) || columns[column] == nil {
+				continue
+			}
+			if err := setCSVField(item.FieldByIndex(columns[column]), value); err != nil {
+				return fmt.Errorf("CSV row %d, column %q: %w", row+2, records[0][column], err)
+			}
+		}
+		if elemType.Kind() == reflect.Ptr {
+			item = item.Addr()
+		}
+		result = reflect.Append(result, item)
+	}
+
+	slice.Set(result)
+	return nil
+}
+
+// csvColumnFields returns, for each header column, the index of the struct field it is decoded into,
+// or nil when no field matches.
+func csvColumnFields(header []string, structType reflect.Type) [][]int {
+	fields := make(map[string][]int)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("csv"); ok {
+			tag, _, _ = strings.Cut(tag, ",")
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		fields[normalizeCSVName(name)] = field.Index
+	}
+
+	columns := make([][]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // Byte order mark written by some exports
+		}
+		columns[i] = fields[normalizeCSVName(name)]
+	}
+	return columns
+}
+
+// normalizeCSVName lowercases a column or field name and drops spaces, hyphens and underscores.
+func normalizeCSVName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(name)))
+}
+
+// setCSVField converts a CSV value to the field's type. Empty values leave the field at its zero value.
+func setCSVField(field reflect.Value, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	if field.Kind() == reflect.Ptr {
+		ptr := reflect.New(field.Type().Elem())
+		if err := setCSVField(ptr.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+
+	if field.CanAddr() {
+		if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return unmarshaler.UnmarshalText([]byte(value))
+		}
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Type() == reflect.TypeOf(time.Duration(0)) {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				return err
+			}
+			field.SetInt(int64(parsed))
+			return nil
+		}
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
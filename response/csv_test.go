@@ -0,0 +1,77 @@
+// response/csv_test.go
+package response
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// preloadRecord mirrors a few columns of the Jamf Pro inventory preload CSV.
+type preloadRecord struct {
+	SerialNumber string     `csv:"Serial Number"`
+	DeviceType   string     // Matched by field name against "Device Type"
+	Quantity     int        `csv:"qty"`
+	Price        *float64   `csv:"price"`
+	Managed      bool       `csv:"managed"`
+	Updated      time.Time  `csv:"updated"`
+	Retired      *time.Time `csv:"retired"`
+	Notes        string     `csv:"-"`
+}
+
+// TestUnmarshalCSVStructs tests decoding CSV records into a slice of structs.
+func TestUnmarshalCSVStructs(t *testing.T) {
+	body := "\ufeffSerial Number,Device Type,QTY,price,managed,updated,retired,notes,unknown\n" +
+		"C02ABC,Computer,2,9.5,true,2024-05-01T10:00:00Z,,secret,x\n" +
+		"DMQXYZ,Mobile Device,,,false,2024-05-02T10:00:00Z,2025-01-01T00:00:00Z\n"
+
+	var out []preloadRecord
+	require.NoError(t, unmarshalCSV(strings.NewReader(body), &out, newTestLogger(), "text/csv"))
+	require.Len(t, out, 2)
+
+	assert.Equal(t, "C02ABC", out[0].SerialNumber)
+	assert.Equal(t, "Computer", out[0].DeviceType)
+	assert.Equal(t, 2, out[0].Quantity)
+	require.NotNil(t, out[0].Price)
+	assert.Equal(t, 9.5, *out[0].Price)
+	assert.True(t, out[0].Managed)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), out[0].Updated)
+	assert.Nil(t, out[0].Retired)
+	assert.Empty(t, out[0].Notes)
+
+	assert.Equal(t, "Mobile Device", out[1].DeviceType)
+	assert.Zero(t, out[1].Quantity)
+	assert.Nil(t, out[1].Price)
+	require.NotNil(t, out[1].Retired)
+	assert.Equal(t, 2025, out[1].Retired.Year())
+}
+
+// TestUnmarshalCSVOutputs tests the other supported and unsupported output parameters.
+func TestUnmarshalCSVOutputs(t *testing.T) {
+	log := newTestLogger()
+	body := "Serial Number,qty\nC02ABC,1\n"
+
+	var records [][]string
+	require.NoError(t, unmarshalCSV(strings.NewReader(body), &records, log, "text/csv"))
+	assert.Equal(t, [][]string{{"Serial Number", "qty"}, {"C02ABC", "1"}}, records)
+
+	var pointers []*preloadRecord
+	require.NoError(t, unmarshalCSV(strings.NewReader(body), &pointers, log, "text/csv"))
+	require.Len(t, pointers, 1)
+	assert.Equal(t, "C02ABC", pointers[0].SerialNumber)
+
+	var headerOnly []preloadRecord
+	require.NoError(t, unmarshalCSV(strings.NewReader("Serial Number,qty\n"), &headerOnly, log, "text/csv"))
+	assert.NotNil(t, headerOnly)
+	assert.Empty(t, headerOnly)
+
+	var invalid []preloadRecord
+	err := unmarshalCSV(strings.NewReader("qty\nmany\n"), &invalid, log, "text/csv")
+	assert.ErrorContains(t, err, `row 2, column "qty"`)
+
+	var values []string
+	assert.Error(t, unmarshalCSV(strings.NewReader(body), &values, log, "text/csv"))
+}
@@ -0,0 +1,96 @@
+// response/decoders.go
+package response
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"go.uber.org/zap"
+)
+
+// Decoder decodes a response body into out. mimeType is the media type of the response, without parameters.
+type Decoder func(reader io.Reader, out interface{}, log logger.Logger, mimeType string) error
+
+var (
+	decodersLock sync.RWMutex
+	decoders     = map[string]Decoder{
+		"application/json": unmarshalJSON,
+		"application/xml":  unmarshalXML,
+		"text/xml":         unmarshalXML,
+		"text/csv":         unmarshalCSV,
+		"+json":            unmarshalJSON,
+		"+xml":             unmarshalXML,
+		"text/*":           unmarshalText,
+	}
+)
+
+// RegisterDecoder makes a decoder available for responses of the given media type, e.g. "application/pkix-cert".
+// The media type may also be a structured syntax suffix such as "+json", which applies to every media type
+// carrying that suffix, or a wildcard such as "text/*". Registering a media type that already has a decoder
+// replaces it, including the built-in decoders. RegisterDecoder panics if the media type is empty or the
+// decoder is nil.
+func RegisterDecoder(mediaType string, decoder Decoder) {
+	if mediaType == "" {
+		panic("response: RegisterDecoder called with an empty media type")
+	}
+	if decoder == nil {
+		panic("response: RegisterDecoder decoder is nil for " + mediaType)
+	}
+
+	decodersLock.Lock()
+	defer decodersLock.Unlock()
+	decoders[strings.ToLower(mediaType)] = decoder
+}
+
+// LookupDecoder returns the decoder for a media type. An exact registration is preferred, followed by the
+// media type's structured syntax suffix ("application/problem+json" uses "+json") and then its wildcard
+// ("text/html" uses "text/*").
+func LookupDecoder(mediaType string) (Decoder, bool) {
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if mediaType == "" {
+		return nil, false
+	}
+
+	decodersLock.RLock()
+	defer decodersLock.RUnlock()
+
+	if decoder, ok := decoders[mediaType]; ok {
+		return decoder, true
+	}
+	if plus := strings.LastIndexByte(mediaType, '+'); plus >= 0 {
+		if decoder, ok := decoders[mediaType[plus:]]; ok {
+			return decoder, true
+		}
+	}
+	if slash := strings.IndexByte(mediaType, '/'); slash >= 0 {
+		if decoder, ok := decoders[mediaType[:slash]+"/*"]; ok {
+			return decoder, true
+		}
+	}
+	return nil, false
+}
+
+// unmarshalText reads a text response into a *string. *[]byte and io.Writer outputs receive the raw body.
+func unmarshalText(reader io.Reader, out interface{}, log logger.Logger, mimeType string) error {
+	switch out := out.(type) {
+	case *string:
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			log.Error("Failed to read text response", zap.Error(err))
+			return err
+		}
+		*out = string(data)
+	case *[]byte, io.Writer:
+		return handleBinaryData(reader, log, out, mimeType, "")
+	default:
+		errMsg := "output parameter is not suitable for text data (*string, *[]byte or io.Writer)"
+		log.Error(errMsg, zap.String("Content-Type", mimeType))
+		return errors.New(errMsg)
+	}
+
+	log.Info("Successfully read text response", zap.String("content type", mimeType))
+	return nil
+}
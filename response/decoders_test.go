@@ -0,0 +1,125 @@
+// response/decoders_test.go
+package response
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/deploymenttheory/go-api-http-client/logger"
+	"github.com/deploymenttheory/go-api-http-client/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLogger returns a mock logger that accepts any log call.
+func newTestLogger() *mocklogger.MockLogger {
+	mockLog := mocklogger.NewMockLogger()
+	mockLog.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Error", mock.Anything).Return(errors.New("logged error")).Maybe()
+	mockLog.On("Error", mock.Anything, mock.Anything).Maybe()
+	return mockLog
+}
+
+// newTestResponse returns a GET response with the given content type and body.
+func newTestResponse(contentType, body string) *http.Response {
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    &http.Request{Method: http.MethodGet},
+	}
+	resp.Header.Set("Content-Type", contentType)
+	return resp
+}
+
+// TestLookupDecoder tests exact, structured suffix and wildcard matching.
+func TestLookupDecoder(t *testing.T) {
+	tests := []struct {
+		mediaType string
+		found     bool
+	}{
+		{"application/json", true},
+		{"Application/JSON", true},
+		{"application/problem+json", true},
+		{"application/vnd.github+json", true},
+		{"application/atom+xml", true},
+		{"text/csv", true},
+		{"text/plain", true},
+		{"text/html", true},
+		{"application/pkix-cert", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mediaType, func(t *testing.T) {
+			_, found := LookupDecoder(tt.mediaType)
+			assert.Equal(t, tt.found, found)
+		})
+	}
+}
+
+// TestRegisterDecoder tests that a registered decoder is used for its media type.
+func TestRegisterDecoder(t *testing.T) {
+	RegisterDecoder("application/x-test-upper", func(reader io.Reader, out interface{}, log logger.Logger, mimeType string) error {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		*out.(*string) = strings.ToUpper(string(data))
+		return nil
+	})
+
+	var out string
+	err := HandleAPISuccessResponse(newTestResponse("application/x-test-upper; charset=utf-8", "shout"), &out, newTestLogger())
+	require.NoError(t, err)
+	assert.Equal(t, "SHOUT", out)
+
+	assert.Panics(t, func() { RegisterDecoder("", unmarshalText) })
+	assert.Panics(t, func() { RegisterDecoder("application/x-nil", nil) })
+}
+
+// TestHandleAPISuccessResponseMediaTypes tests decoding of media types beyond plain JSON and XML.
+func TestHandleAPISuccessResponseMediaTypes(t *testing.T) {
+	log := newTestLogger()
+
+	t.Run("Structured JSON suffix", func(t *testing.T) {
+		var out struct {
+			Login string `json:"login"`
+		}
+		require.NoError(t, HandleAPISuccessResponse(newTestResponse("application/vnd.github+json", `{"login":"octocat"}`), &out, log))
+		assert.Equal(t, "octocat", out.Login)
+	})
+
+	t.Run("Text into string", func(t *testing.T) {
+		var out string
+		require.NoError(t, HandleAPISuccessResponse(newTestResponse("text/plain; charset=utf-8", "pong"), &out, log))
+		assert.Equal(t, "pong", out)
+	})
+
+	t.Run("Undecoded media type into bytes", func(t *testing.T) {
+		var out []byte
+		require.NoError(t, HandleAPISuccessResponse(newTestResponse("application/pkix-cert", "\x30\x82"), &out, log))
+		assert.Equal(t, []byte("\x30\x82"), out)
+	})
+
+	t.Run("CSV into bytes", func(t *testing.T) {
+		var out []byte
+		require.NoError(t, HandleAPISuccessResponse(newTestResponse("text/csv", "a,b\n"), &out, log))
+		assert.Equal(t, "a,b\n", string(out))
+	})
+
+	t.Run("Undecoded media type into struct", func(t *testing.T) {
+		var out struct{}
+		assert.Error(t, HandleAPISuccessResponse(newTestResponse("application/pkix-cert", "\x30\x82"), &out, log))
+	})
+
+	t.Run("Text into struct", func(t *testing.T) {
+		var out struct{}
+		assert.Error(t, HandleAPISuccessResponse(newTestResponse("text/html", "<p>hi</p>"), &out, log))
+	})
+}
@@ -9,22 +9,53 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/antchfx/xmlquery"
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
 	"github.com/deploymenttheory/go-api-http-client/logger"
+	"github.com/deploymenttheory/go-api-http-client/ratehandler"
 	"golang.org/x/net/html"
 )
 
 // APIError represents an api error response.
 type APIError struct {
-	StatusCode  int      `json:"status_code"`       // HTTP status code
-	Method      string   `json:"method"`            // HTTP method used for the request
-	URL         string   `json:"url"`               // The URL of the HTTP request
-	Message     string   `json:"message"`           // Summary of the error
-	Details     []string `json:"details,omitempty"` // Detailed error messages, if any
-	RawResponse string   `json:"raw_response"`      // Raw response body for debugging
+	StatusCode       int             `json:"status_code"`                 // HTTP status code
+	Method           string          `json:"method"`                      // HTTP method used for the request
+	URL              string          `json:"url"`                         // The URL of the HTTP request
+	Message          string          `json:"message"`                     // Summary of the error
+	Code             string          `json:"code,omitempty"`              // API specific error code, if any
+	Target           string          `json:"target,omitempty"`            // Field or parameter the error relates to, if any
+	Details          []string        `json:"details,omitempty"`           // Detailed error messages, if any
+	RequestID        string          `json:"request_id,omitempty"`        // Request ID assigned by the server, for support cases
+	CorrelationID    string          `json:"correlation_id,omitempty"`    // Client request or correlation ID echoed by the server
+	DocumentationURL string          `json:"documentation_url,omitempty"` // Link to documentation about the error
+	Problem          *ProblemDetails `json:"problem,omitempty"`           // RFC 7807 problem details, for application/problem+json responses
+	RetryAfter       time.Duration   `json:"retry_after,omitempty"`       // Wait requested by the server through rate limit headers, if any
+	RawResponse      string          `json:"raw_response"`                // Raw response body for debugging
 }
 
+// Unwrap returns the apierrors sentinel for the status code, so that callers can use errors.Is, e.g.
+// errors.Is(err, apierrors.ErrNotFound). A 429 status, or a 403 with rate limit headers as sent by GitHub,
+// unwraps to an *apierrors.RateLimitError carrying RetryAfter.
+func (e *APIError) Unwrap() error {
+	if e.StatusCode == http.StatusTooManyRequests || (e.StatusCode == http.StatusForbidden && e.RetryAfter > 0) {
+		return apierrors.NewRateLimitError(e.RetryAfter)
+	}
+	return apierrors.ForStatusCode(e.StatusCode)
+}
+
+// ErrorParser parses an API specific error response body into apiError. It may instead return an error of its
+// own type, which is then returned in place of apiError; such errors should wrap apiError so callers can still
+// reach it with errors.As.
+type ErrorParser func(resp *http.Response, body []byte, apiError *APIError) error
+
+// Request and correlation ID headers checked on every error response, in order of preference.
+var (
+	requestIDHeaders     = []string{"X-Request-Id", "Request-Id", "X-GitHub-Request-Id", "X-Amzn-RequestId"}
+	correlationIDHeaders = []string{"X-Correlation-Id", "Client-Request-Id", "X-Client-Request-Id"}
+)
+
 // Error returns a string representation of the APIError, making it compatible with the error interface.
 func (e *APIError) Error() string {
 	// Attempt to marshal the APIError instance into a JSON string.
@@ -44,29 +75,57 @@ func (e *APIError) Error() string {
 
 // HandleAPIErrorResponse handles the HTTP error response from an API and logs the error.
 func HandleAPIErrorResponse(resp *http.Response, log logger.Logger) *APIError {
+	apiError, _ := parseAPIErrorResponse(resp, log)
+	return apiError
+}
+
+// HandleAPIErrorResponseWithParser handles the HTTP error response like HandleAPIErrorResponse, then lets an
+// API specific parser refine the result. The parser's error is returned if it returns one, otherwise the APIError.
+func HandleAPIErrorResponseWithParser(resp *http.Response, log logger.Logger, parser ErrorParser) error {
+	apiError, bodyBytes := parseAPIErrorResponse(resp, log)
+	if parser == nil || bodyBytes == nil {
+		return apiError
+	}
+	if err := parser(resp, bodyBytes, apiError); err != nil {
+		return err
+	}
+	return apiError
+}
+
+// parseAPIErrorResponse reads the error response body and parses it according to its content type.
+// It returns the APIError together with the body, which is nil if it could not be read.
+func parseAPIErrorResponse(resp *http.Response, log logger.Logger) (*APIError, []byte) {
 	apiError := &APIError{
-		StatusCode: resp.StatusCode,
-		Method:     resp.Request.Method,
-		URL:        resp.Request.URL.String(),
-		Message:    "API Error Response",
+		StatusCode:    resp.StatusCode,
+		Method:        resp.Request.Method,
+		URL:           resp.Request.URL.String(),
+		Message:       "API Error Response",
+		RequestID:     firstHeader(resp.Header, requestIDHeaders),
+		CorrelationID: firstHeader(resp.Header, correlationIDHeaders),
+	}
+	if retryAfter := ratehandler.ParseRateLimitHeaders(resp, log); retryAfter > 0 {
+		apiError.RetryAfter = retryAfter
 	}
 
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
 		apiError.RawResponse = "Failed to read response body"
 		log.LogError("error_reading_response_body", resp.Request.Method, resp.Request.URL.String(), apiError.StatusCode, resp.Status, err, apiError.RawResponse)
-		return apiError
+		return apiError, nil
 	}
 
 	mimeType, _ := ParseContentTypeHeader(resp.Header.Get("Content-Type"))
-	switch mimeType {
-	case "application/json":
+	mimeType = strings.ToLower(mimeType)
+	switch {
+	case mimeType == "application/problem+json":
+		parseProblemResponse(bodyBytes, apiError, log, resp)
+	case mimeType == "application/json" || strings.HasSuffix(mimeType, "+json"):
 		parseJSONResponse(bodyBytes, apiError, log, resp)
-	case "application/xml", "text/xml":
+	case mimeType == "application/xml" || mimeType == "text/xml" || strings.HasSuffix(mimeType, "+xml"):
 		parseXMLResponse(bodyBytes, apiError, log, resp)
-	case "text/html":
+	case mimeType == "text/html":
 		parseHTMLResponse(bodyBytes, apiError, log, resp)
-	case "text/plain":
+	case mimeType == "text/plain":
 		parseTextResponse(bodyBytes, apiError, log, resp)
 	default:
 		apiError.RawResponse = string(bodyBytes)
@@ -74,15 +133,38 @@ func HandleAPIErrorResponse(resp *http.Response, log logger.Logger) *APIError {
 		log.LogError("unknown_content_type_error", resp.Request.Method, resp.Request.URL.String(), apiError.StatusCode, "Unknown content type", nil, apiError.RawResponse)
 	}
 
-	return apiError
+	return apiError, bodyBytes
+}
+
+// firstHeader returns the value of the first of the named headers present in the header set.
+func firstHeader(header http.Header, names []string) string {
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
 }
 
 // parseJSONResponse attempts to parse the JSON error response and update the APIError structure.
+// Only the message and string details members are read, so API specific members of other shapes cannot
+// make the body unparsable; API handlers can parse those with an ErrorParser.
 func parseJSONResponse(bodyBytes []byte, apiError *APIError, log logger.Logger, resp *http.Response) {
-	if err := json.Unmarshal(bodyBytes, apiError); err != nil {
+	var body struct {
+		Message *string         `json:"message"`
+		Details json.RawMessage `json:"details"`
+	}
+	if err := json.Unmarshal(bodyBytes, &body); err != nil {
 		apiError.RawResponse = string(bodyBytes)
 		log.LogError("json_parsing_error", resp.Request.Method, resp.Request.URL.String(), apiError.StatusCode, resp.Status, err, apiError.RawResponse)
 	} else {
+		if body.Message != nil {
+			apiError.Message = *body.Message
+		}
+		var details []string
+		if json.Unmarshal(body.Details, &details) == nil {
+			apiError.Details = append(apiError.Details, details...)
+		}
 		if apiError.Message == "" {
 			apiError.Message = "An unknown error occurred"
 		}
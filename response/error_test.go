@@ -0,0 +1,156 @@
+// response/error_test.go
+package response
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client/apierrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestErrorResponse returns an error response with the given status, content type and body.
+func newTestErrorResponse(statusCode int, contentType, body string) *http.Response {
+	resp := &http.Response{
+		StatusCode: statusCode,
+		Status:     http.StatusText(statusCode),
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    &http.Request{Method: http.MethodPost, URL: &url.URL{Scheme: "https", Host: "api.example.com", Path: "/items"}},
+	}
+	resp.Header.Set("Content-Type", contentType)
+	return resp
+}
+
+// TestHandleAPIErrorResponseProblemDetails tests RFC 7807 problem details parsing.
+func TestHandleAPIErrorResponseProblemDetails(t *testing.T) {
+	log := newTestLogger()
+	log.On("LogError", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	body := `{"type":"https://example.com/probs/out-of-credit","title":"You do not have enough credit.","status":403,` +
+		`"detail":"Your current balance is 30, but that costs 50.","instance":"/account/12345/msgs/abc","balance":30}`
+	resp := newTestErrorResponse(http.StatusForbidden, "application/problem+json; charset=utf-8", body)
+	resp.Header.Set("X-Request-Id", "req-1")
+
+	apiError := HandleAPIErrorResponse(resp, log)
+	require.NotNil(t, apiError.Problem)
+	assert.Equal(t, "https://example.com/probs/out-of-credit", apiError.Code)
+	assert.Equal(t, "Your current balance is 30, but that costs 50.", apiError.Message)
+	assert.Equal(t, []string{"You do not have enough credit."}, apiError.Details)
+	assert.Equal(t, "req-1", apiError.RequestID)
+	assert.Equal(t, 403, apiError.Problem.Status)
+	assert.Equal(t, "/account/12345/msgs/abc", apiError.Problem.Instance)
+	assert.JSONEq(t, "30", string(apiError.Problem.Extensions["balance"]))
+}
+
+// TestHandleAPIErrorResponseJSON tests that JSON error bodies with members of unexpected types still yield a message.
+func TestHandleAPIErrorResponseJSON(t *testing.T) {
+	log := newTestLogger()
+	log.On("LogError", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	resp := newTestErrorResponse(http.StatusBadRequest, "application/vnd.example+json", `{"code":42,"message":"bad item","details":[{"x":1}]}`)
+	apiError := HandleAPIErrorResponse(resp, log)
+	assert.Equal(t, "bad item", apiError.Message)
+	assert.Empty(t, apiError.Details)
+}
+
+// customAPIError is an API specific error type wrapping the APIError.
+type customAPIError struct {
+	*APIError
+	Reason string
+}
+
+func (e *customAPIError) Unwrap() error { return e.APIError }
+
+// TestHandleAPIErrorResponseWithParser tests that an API specific parser refines or replaces the APIError.
+func TestHandleAPIErrorResponseWithParser(t *testing.T) {
+	log := newTestLogger()
+	log.On("LogError", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	t.Run("Fills APIError", func(t *testing.T) {
+		resp := newTestErrorResponse(http.StatusConflict, "application/json", `{"fault":{"id":"E1"}}`)
+		err := HandleAPIErrorResponseWithParser(resp, log, func(resp *http.Response, body []byte, apiError *APIError) error {
+			assert.Contains(t, string(body), "E1")
+			apiError.Code = "E1"
+			return nil
+		})
+
+		var apiError *APIError
+		require.True(t, errors.As(err, &apiError))
+		assert.Equal(t, "E1", apiError.Code)
+		assert.Equal(t, http.StatusConflict, apiError.StatusCode)
+	})
+
+	t.Run("Returns API specific error", func(t *testing.T) {
+		resp := newTestErrorResponse(http.StatusConflict, "application/json", `{"message":"locked"}`)
+		err := HandleAPIErrorResponseWithParser(resp, log, func(resp *http.Response, body []byte, apiError *APIError) error {
+			return &customAPIError{APIError: apiError, Reason: "locked"}
+		})
+
+		var custom *customAPIError
+		require.True(t, errors.As(err, &custom))
+		assert.Equal(t, "locked", custom.Reason)
+
+		var apiError *APIError
+		require.True(t, errors.As(fmt.Errorf("wrapped: %w", err), &apiError))
+		assert.Equal(t, "locked", apiError.Message)
+	})
+}
+
+// TestAPIErrorSentinels tests that APIError matches the apierrors sentinel for its status code.
+func TestAPIErrorSentinels(t *testing.T) {
+	log := newTestLogger()
+	log.On("LogError", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	tests := []struct {
+		statusCode int
+		sentinel   error
+	}{
+		{http.StatusUnauthorized, apierrors.ErrUnauthorized},
+		{http.StatusForbidden, apierrors.ErrForbidden},
+		{http.StatusNotFound, apierrors.ErrNotFound},
+		{http.StatusConflict, apierrors.ErrConflict},
+		{http.StatusBadGateway, apierrors.ErrTransient},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			var err error = HandleAPIErrorResponse(newTestErrorResponse(tt.statusCode, "text/plain", "failed"), log)
+			assert.ErrorIs(t, err, tt.sentinel)
+			assert.NotErrorIs(t, err, apierrors.ErrRateLimited)
+		})
+	}
+
+	var err error = HandleAPIErrorResponse(newTestErrorResponse(http.StatusBadRequest, "text/plain", "failed"), log)
+	assert.Nil(t, errors.Unwrap(err))
+}
+
+// TestAPIErrorRateLimited tests that rate limited responses carry the retry-after value, including GitHub's
+// 403 responses with rate limit headers.
+func TestAPIErrorRateLimited(t *testing.T) {
+	log := newTestLogger()
+	log.On("LogError", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	resp := newTestErrorResponse(http.StatusTooManyRequests, "text/plain", "slow down")
+	resp.Header.Set("Retry-After", "30")
+	var err error = HandleAPIErrorResponse(resp, log)
+
+	var rateLimited *apierrors.RateLimitError
+	require.ErrorAs(t, err, &rateLimited)
+	assert.Equal(t, 30*time.Second, rateLimited.RetryAfter)
+
+	resp = newTestErrorResponse(http.StatusForbidden, "application/json", `{"message":"API rate limit exceeded"}`)
+	resp.Header.Set("X-RateLimit-Remaining", "0")
+	resp.Header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+	err = HandleAPIErrorResponse(resp, log)
+	assert.ErrorIs(t, err, apierrors.ErrRateLimited)
+	assert.NotErrorIs(t, err, apierrors.ErrForbidden)
+}
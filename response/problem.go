@@ -0,0 +1,71 @@
+// response/problem.go
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/deploymenttheory/go-api-http-client/logger"
+)
+
+// ProblemDetails represents an RFC 7807 problem details object, served as application/problem+json.
+type ProblemDetails struct {
+	Type       string                     `json:"type,omitempty"`     // URI reference identifying the problem type; "about:blank" when absent
+	Title      string                     `json:"title,omitempty"`    // Short summary of the problem type
+	Status     int                        `json:"status,omitempty"`   // HTTP status code generated by the origin server
+	Detail     string                     `json:"detail,omitempty"`   // Explanation specific to this occurrence of the problem
+	Instance   string                     `json:"instance,omitempty"` // URI reference identifying this occurrence of the problem
+	Extensions map[string]json.RawMessage `json:"-"`                  // Any further members of the problem object
+}
+
+// problemMembers are the members of a problem details object defined by RFC 7807.
+var problemMembers = []string{"type", "title", "status", "detail", "instance"}
+
+// ParseProblemDetails decodes an RFC 7807 problem details object. Members other than those defined by
+// the RFC are kept in Extensions.
+func ParseProblemDetails(body []byte) (*ProblemDetails, error) {
+	var problem ProblemDetails
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return nil, err
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil {
+		return nil, err
+	}
+	for _, member := range problemMembers {
+		delete(members, member)
+	}
+	if len(members) > 0 {
+		problem.Extensions = members
+	}
+	return &problem, nil
+}
+
+// parseProblemResponse parses an RFC 7807 problem details response and updates the APIError structure.
+// The problem type becomes the error code and the detail, or else the title, becomes the message.
+func parseProblemResponse(bodyBytes []byte, apiError *APIError, log logger.Logger, resp *http.Response) {
+	problem, err := ParseProblemDetails(bodyBytes)
+	if err != nil {
+		apiError.RawResponse = string(bodyBytes)
+		log.LogError("problem_json_parsing_error", resp.Request.Method, resp.Request.URL.String(), apiError.StatusCode, resp.Status, err, apiError.RawResponse)
+		return
+	}
+
+	apiError.Problem = problem
+	if problem.Type != "" && problem.Type != "about:blank" {
+		apiError.Code = problem.Type
+	}
+	switch {
+	case problem.Detail != "":
+		apiError.Message = problem.Detail
+	case problem.Title != "":
+		apiError.Message = problem.Title
+	}
+	if problem.Title != "" && problem.Detail != "" {
+		apiError.Details = append(apiError.Details, strings.TrimSpace(problem.Title))
+	}
+
+	log.LogError("problem_json_error_detected", resp.Request.Method, resp.Request.URL.String(), apiError.StatusCode, resp.Status, nil, apiError.Message)
+}
@@ -1,6 +1,6 @@
 // response/success.go
 /* Responsible for handling successful API responses. It reads the response body, logs the raw response details,
-and unmarshals the response based on the content type (JSON or XML). */
+and unmarshals the response with the decoder registered for its content type. */
 package response
 
 import (
@@ -17,16 +17,6 @@ import (
 	"go.uber.org/zap"
 )
 
-// contentHandler defines the signature for unmarshaling content from an io.Reader.
-type contentHandler func(io.Reader, interface{}, logger.Logger, string) error
-
-// responseUnmarshallers maps MIME types to the corresponding contentHandler functions.
-var responseUnmarshallers = map[string]contentHandler{
-	"application/json": unmarshalJSON,
-	"application/xml":  unmarshalXML,
-	"text/xml":         unmarshalXML,
-}
-
 // HandleAPISuccessResponse reads the response body, logs the raw response details, and unmarshals the response based on the content type.
 func HandleAPISuccessResponse(resp *http.Response, out interface{}, log logger.Logger) error {
 	if resp.Request.Method == "DELETE" {
@@ -50,8 +40,11 @@ func HandleAPISuccessResponse(resp *http.Response, out interface{}, log logger.L
 	mimeType, _ := ParseContentTypeHeader(resp.Header.Get("Content-Type"))
 	contentDisposition := resp.Header.Get("Content-Disposition")
 
-	if handler, ok := responseUnmarshallers[mimeType]; ok {
-		return handler(bodyReader, out, log, mimeType)
+	if isBinaryOutput(out) {
+		// Raw outputs receive the body as is, whatever its media type, e.g. certificates, images or CSV files
+		return handleBinaryData(bodyReader, log, out, mimeType, contentDisposition)
+	} else if decoder, ok := LookupDecoder(mimeType); ok {
+		return decoder(bodyReader, out, log, mimeType)
 	} else if isBinaryData(mimeType, contentDisposition) {
 		return handleBinaryData(bodyReader, log, out, mimeType, contentDisposition)
 	} else {
@@ -102,6 +95,15 @@ func isBinaryData(contentType, contentDisposition string) bool {
 	return strings.Contains(contentType, "application/octet-stream") || strings.HasPrefix(contentDisposition, "attachment")
 }
 
+// isBinaryOutput checks if the output parameter can receive raw response data.
+func isBinaryOutput(out interface{}) bool {
+	switch out.(type) {
+	case *[]byte, io.Writer:
+		return true
+	}
+	return false
+}
+
 // handleBinaryData reads binary data from an io.Reader and stores it in *[]byte or streams it to an io.Writer.
 func handleBinaryData(reader io.Reader, log logger.Logger, out interface{}, mimeType, contentDisposition string) error {
 	// Check if the output interface is either *[]byte or io.Writer